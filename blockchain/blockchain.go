@@ -74,19 +74,11 @@ type Blockchain struct {
 
 	stream *eventStream // Event subscriptions
 
-	gpAverage *gasPriceAverage // A reference to the average gas price
+	bloomIndexer *bloomIndexer // The bloom bits index of the logs
 
 	writeLock sync.Mutex
 }
 
-// gasPriceAverage keeps track of the average gas price (rolling average)
-type gasPriceAverage struct {
-	sync.RWMutex
-
-	price *big.Int // The average gas price that gets queried
-	count *big.Int // Param used in the avg. gas price calculation
-}
-
 type Verifier interface {
 	VerifyHeader(header *types.Header) error
 	ProcessHeaders(headers []*types.Header) error
@@ -109,84 +101,6 @@ type BlockResult struct {
 	TotalGas uint64
 }
 
-// updateGasPriceAvg updates the rolling average value of the gas price
-func (b *Blockchain) updateGasPriceAvg(newValues []*big.Int) {
-	b.gpAverage.Lock()
-	defer b.gpAverage.Unlock()
-
-	// Sum the values for quick reference
-	sum := big.NewInt(0)
-	for _, val := range newValues {
-		sum = sum.Add(sum, val)
-	}
-
-	// There is no previous average data,
-	// so this new value set will instantiate it
-	if b.gpAverage.count.Uint64() == 0 {
-		b.calcArithmeticAverage(newValues, sum)
-
-		return
-	}
-
-	// There is existing average data,
-	// use it to generate a new average
-	b.calcRollingAverage(newValues, sum)
-}
-
-// calcArithmeticAverage calculates and sets the arithmetic average
-// of the passed in data set
-func (b *Blockchain) calcArithmeticAverage(newValues []*big.Int, sum *big.Int) {
-	newAverageCount := big.NewInt(int64(len(newValues)))
-	newAverage := sum.Div(sum, newAverageCount)
-
-	b.gpAverage.price = newAverage
-	b.gpAverage.count = newAverageCount
-}
-
-// calcRollingAverage calculates the new average based on the
-// moving average formula:
-// new average = old average * (n-len(M))/n + (sum of values in M)/n)
-// where n is the old average data count, and M is the new data set
-func (b *Blockchain) calcRollingAverage(newValues []*big.Int, sum *big.Int) {
-	var (
-		// Save references to old counts
-		oldCount   = b.gpAverage.count
-		oldAverage = b.gpAverage.price
-
-		inputSetCount = big.NewInt(0).SetInt64(int64(len(newValues)))
-	)
-
-	// old average * (n-len(M))/n
-	newAverage := big.NewInt(0).Div(
-		big.NewInt(0).Mul(
-			oldAverage,
-			big.NewInt(0).Sub(oldCount, inputSetCount),
-		),
-		oldCount,
-	)
-
-	// + (sum of values in M)/n
-	newAverage.Add(
-		newAverage,
-		big.NewInt(0).Div(
-			sum,
-			oldCount,
-		),
-	)
-
-	// Update the references
-	b.gpAverage.price = newAverage
-	b.gpAverage.count = inputSetCount.Add(inputSetCount, b.gpAverage.count)
-}
-
-// GetAvgGasPrice returns the average gas price for the chain
-func (b *Blockchain) GetAvgGasPrice() *big.Int {
-	b.gpAverage.RLock()
-	defer b.gpAverage.RUnlock()
-
-	return b.gpAverage.price
-}
-
 // NewBlockchain creates a new blockchain object
 func NewBlockchain(
 	logger hclog.Logger,
@@ -204,12 +118,10 @@ func NewBlockchain(
 		executor:  executor,
 		txSigner:  txSigner,
 		stream:    &eventStream{},
-		gpAverage: &gasPriceAverage{
-			price: big.NewInt(0),
-			count: big.NewInt(0),
-		},
 	}
 
+	b.bloomIndexer = newBloomIndexer(b.logger, db)
+
 	if err := b.initCaches(defaultCacheSize); err != nil {
 		return nil, err
 	}
@@ -259,6 +171,11 @@ func (b *Blockchain) ComputeGenesis() error {
 			return fmt.Errorf("genesis file does not match current genesis")
 		}
 
+		var err error
+		if head, err = b.repairHead(head); err != nil {
+			return err
+		}
+
 		header, ok := b.GetHeaderByHash(head)
 		if !ok {
 			return fmt.Errorf("failed to get header with hash %s", head.String())
@@ -278,6 +195,9 @@ func (b *Blockchain) ComputeGenesis() error {
 		)
 
 		b.setCurrentHeader(header, diff)
+
+		// index the sections missing in the bloom bits index
+		b.bloomIndexer.notify(header.Number)
 	} else {
 		// empty storage, write the genesis
 		if err := b.writeGenesis(b.config.Genesis); err != nil {
@@ -290,6 +210,79 @@ func (b *Blockchain) ComputeGenesis() error {
 	return nil
 }
 
+// repairHead checks that the head references a complete block.
+// A crash in the middle of a write by an older version could leave the head torn,
+// in which case the head is rolled back to the latest complete canonical block
+func (b *Blockchain) repairHead(head types.Hash) (types.Hash, error) {
+	number, ok := b.db.ReadHeadNumber()
+	if header, err := b.db.ReadHeader(head); err == nil {
+		number, ok = header.Number, true
+	}
+
+	if !ok {
+		return types.ZeroHash, fmt.Errorf("failed to read head number")
+	}
+
+	for {
+		hash, ok := b.db.ReadCanonicalHash(number)
+		if ok && b.isCompleteBlock(number, hash) {
+			if hash == head {
+				return head, nil
+			}
+
+			b.logger.Warn(
+				"Repairing torn head",
+				"hash", head.String(),
+				"new hash", hash.String(),
+				"new number", number,
+			)
+
+			batch := b.db.NewBatch()
+
+			if err := batch.WriteHeadHash(hash); err != nil {
+				return types.ZeroHash, err
+			}
+
+			if err := batch.WriteHeadNumber(number); err != nil {
+				return types.ZeroHash, err
+			}
+
+			if err := batch.WriteCanonicalHash(number, hash); err != nil {
+				return types.ZeroHash, err
+			}
+
+			return hash, batch.Write()
+		}
+
+		if number == 0 {
+			return types.ZeroHash, fmt.Errorf("failed to find a complete block to repair the head")
+		}
+
+		number--
+	}
+}
+
+// isCompleteBlock checks if all the data of the canonical block is in the storage
+func (b *Blockchain) isCompleteBlock(number uint64, hash types.Hash) bool {
+	header, err := b.db.ReadHeader(hash)
+	if err != nil || header.Number != number {
+		return false
+	}
+
+	if _, ok := b.db.ReadTotalDifficulty(hash); !ok {
+		return false
+	}
+
+	if number == 0 {
+		// the genesis has no receipts
+		return true
+	}
+
+	_, err = b.db.ReadReceipts(hash)
+
+	return err == nil
+}
+
 func (b *Blockchain) GetConsensus() Verifier {
 	return b.consensus
 }
@@ -403,16 +396,25 @@ func (b *Blockchain) writeGenesisImpl(header *types.Header) error {
 	// Update the reference
 	b.genesis = header.Hash
 
+	batch := b.db.NewBatch()
+
 	// Update the DB
-	if err := b.db.WriteHeader(header); err != nil {
+	if err := batch.WriteHeader(header); err != nil {
 		return err
 	}
 
 	// Advance the head
-	if _, err := b.advanceHead(header); err != nil {
+	newTD, err := b.writeHead(batch, header)
+	if err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
 		return err
 	}
 
+	b.setCurrentHeader(header, newTD)
+
 	// Create an event and send it to the stream
 	event := &Event{}
 	event.AddNewHeader(header)
@@ -440,15 +442,15 @@ func (b *Blockchain) GetTD(hash types.Hash) (*big.Int, bool) {
 	return b.readTotalDifficulty(hash)
 }
 
-// writeCanonicalHeader writes the new header
-func (b *Blockchain) writeCanonicalHeader(event *Event, h *types.Header) error {
+// writeCanonicalHeader writes the new header to the batch
+func (b *Blockchain) writeCanonicalHeader(batch storage.Batch, event *Event, h *types.Header) error {
 	parentTD, ok := b.readTotalDifficulty(h.ParentHash)
 	if !ok {
 		return fmt.Errorf("parent difficulty not found")
 	}
 
 	newTD := big.NewInt(0).Add(parentTD, new(big.Int).SetUint64(h.Difficulty))
-	if err := b.db.WriteCanonicalHeader(h, newTD); err != nil {
+	if err := batch.WriteCanonicalHeader(h, newTD); err != nil {
 		return err
 	}
 
@@ -456,25 +458,43 @@ func (b *Blockchain) writeCanonicalHeader(event *Event, h *types.Header) error {
 	event.AddNewHeader(h)
 	event.SetDifficulty(newTD)
 
-	b.setCurrentHeader(h, newTD)
-
 	return nil
 }
 
 // advanceHead Sets the passed in header as the new head of the chain
 func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
+	batch := b.db.NewBatch()
+
+	newTD, err := b.writeHead(batch, newHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	// Update the blockchain reference
+	b.setCurrentHeader(newHeader, newTD)
+
+	return newTD, nil
+}
+
+// writeHead writes the passed in header as the new head of the chain to the batch.
+// Returns the new total difficulty
+func (b *Blockchain) writeHead(batch storage.Batch, newHeader *types.Header) (*big.Int, error) {
 	// Write the current head hash into storage
-	if err := b.db.WriteHeadHash(newHeader.Hash); err != nil {
+	if err := batch.WriteHeadHash(newHeader.Hash); err != nil {
 		return nil, err
 	}
 
 	// Write the current head number into storage
-	if err := b.db.WriteHeadNumber(newHeader.Number); err != nil {
+	if err := batch.WriteHeadNumber(newHeader.Number); err != nil {
 		return nil, err
 	}
 
 	// Matches the current head number with the current hash
-	if err := b.db.WriteCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
+	if err := batch.WriteCanonicalHash(newHeader.Number, newHeader.Hash); err != nil {
 		return nil, err
 	}
 
@@ -492,13 +512,10 @@ func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
 
 	// Calculate the new total difficulty
 	newTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(newHeader.Difficulty))
-	if err := b.db.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
+	if err := batch.WriteTotalDifficulty(newHeader.Hash, newTD); err != nil {
 		return nil, err
 	}
 
-	// Update the blockchain reference
-	b.setCurrentHeader(newHeader, newTD)
-
 	return newTD, nil
 }
 
@@ -604,6 +621,22 @@ func (b *Blockchain) GetHeaderByNumber(n uint64) (*types.Header, bool) {
 	return h, true
 }
 
+// RegenerateState re-executes the stored canonical block to rebuild its state,
+// e.g. after the state was lost on an unclean shutdown. The head of the chain is kept,
+// the blocks of the finalizing consensus engines can't be produced again
+func (b *Blockchain) RegenerateState(block *types.Block) error {
+	blockResult, err := b.executeBlockTransactions(block)
+	if err != nil {
+		return fmt.Errorf("unable to execute block transactions, %w", err)
+	}
+
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return nil
+}
+
 // WriteHeaders writes an array of headers
 func (b *Blockchain) WriteHeaders(headers []*types.Header) error {
 	return b.WriteHeadersWithBodies(headers)
@@ -636,8 +669,14 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 
 	// Write the actual headers
 	for _, h := range headers {
+		batch := b.db.NewBatch()
+
 		event := &Event{}
-		if err := b.writeHeaderImpl(event, h); err != nil {
+		if err := b.writeHeaderImpl(batch, event, h); err != nil {
+			return err
+		}
+
+		if err := b.commitHeader(batch, event, h); err != nil {
 			return err
 		}
 
@@ -870,20 +909,25 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 
 	header := block.Header
 
-	if err := b.writeBody(block); err != nil {
+	// The block is written atomically,
+	// so a crash can't leave the head referencing missing data
+	batch := b.db.NewBatch()
+
+	if err := b.writeBody(batch, block); err != nil {
 		return err
 	}
 
 	// Write the header to the chain
 	evnt := &Event{Source: source}
-	if err := b.writeHeaderImpl(evnt, header); err != nil {
+	if err := b.writeHeaderImpl(batch, evnt, header); err != nil {
 		return err
 	}
 
-	// write the receipts, do it only after the header has been written.
-	// Otherwise, a client might ask for a header once the receipt is valid,
-	// but before it is written into the storage
-	if err := b.db.WriteReceipts(block.Hash(), fblock.Receipts); err != nil {
+	if err := batch.WriteReceipts(block.Hash(), fblock.Receipts); err != nil {
+		return err
+	}
+
+	if err := b.commitHeader(batch, evnt, header); err != nil {
 		return err
 	}
 
@@ -894,8 +938,8 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 
 	b.dispatchEvent(evnt)
 
-	// Update the average gas price
-	b.updateGasPriceAvgWithBlock(block)
+	// Index the section of the logs blooms completed by the block, if any
+	b.bloomIndexer.notify(b.Header().Number)
 
 	logArgs := []interface{}{
 		"number", header.Number,
@@ -929,13 +973,17 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 
 	header := block.Header
 
-	if err := b.writeBody(block); err != nil {
+	// The block is written atomically,
+	// so a crash can't leave the head referencing missing data
+	batch := b.db.NewBatch()
+
+	if err := b.writeBody(batch, block); err != nil {
 		return err
 	}
 
 	// Write the header to the chain
 	evnt := &Event{Source: source}
-	if err := b.writeHeaderImpl(evnt, header); err != nil {
+	if err := b.writeHeaderImpl(batch, evnt, header); err != nil {
 		return err
 	}
 
@@ -945,10 +993,11 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return receiptsErr
 	}
 
-	// write the receipts, do it only after the header has been written.
-	// Otherwise, a client might ask for a header once the receipt is valid,
-	// but before it is written into the storage
-	if err := b.db.WriteReceipts(block.Hash(), blockReceipts); err != nil {
+	if err := batch.WriteReceipts(block.Hash(), blockReceipts); err != nil {
+		return err
+	}
+
+	if err := b.commitHeader(batch, evnt, header); err != nil {
 		return err
 	}
 
@@ -959,8 +1008,8 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 
 	b.dispatchEvent(evnt)
 
-	// Update the average gas price
-	b.updateGasPriceAvgWithBlock(block)
+	// Index the section of the logs blooms completed by the block, if any
+	b.bloomIndexer.notify(b.Header().Number)
 
 	logArgs := []interface{}{
 		"number", header.Number,
@@ -1018,26 +1067,9 @@ func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt,
 	return extractedReceipts, nil
 }
 
-// updateGasPriceAvgWithBlock extracts the gas price information from the
-// block, and updates the average gas price for the chain accordingly
-func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
-	if len(block.Transactions) < 1 {
-		// No transactions in the block,
-		// so no gas price average to update
-		return
-	}
-
-	gasPrices := make([]*big.Int, len(block.Transactions))
-	for i, transaction := range block.Transactions {
-		gasPrices[i] = transaction.GetGasPrice(block.Header.BaseFee)
-	}
-
-	b.updateGasPriceAvg(gasPrices)
-}
-
-// writeBody writes the block body to the DB.
+// writeBody writes the block body to the batch.
 // Additionally, it also updates the txn lookup, for txnHash -> block lookups
-func (b *Blockchain) writeBody(block *types.Block) error {
+func (b *Blockchain) writeBody(batch storage.Batch, block *types.Block) error {
 	// Recover 'from' field in tx before saving
 	// Because the block passed from the consensus layer doesn't have from field in tx,
 	// due to missing encoding in RLP
@@ -1046,13 +1078,13 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 	}
 
 	// Write the full body (txns + receipts)
-	if err := b.db.WriteBody(block.Header.Hash, block.Body()); err != nil {
+	if err := batch.WriteBody(block.Header.Hash, block.Body()); err != nil {
 		return err
 	}
 
 	// Write txn lookups (txHash -> block)
 	for _, txn := range block.Transactions {
-		if err := b.db.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
+		if err := batch.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
 			return err
 		}
 	}
@@ -1187,17 +1219,18 @@ func (b *Blockchain) dispatchEvent(evnt *Event) {
 	b.stream.push(evnt)
 }
 
-// writeHeaderImpl writes a block and the data, assumes the genesis is already set
-func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
+// writeHeaderImpl writes a block and the data to the batch, assumes the genesis is already set.
+// The head is updated once the batch is committed by commitHeader
+func (b *Blockchain) writeHeaderImpl(batch storage.Batch, evnt *Event, header *types.Header) error {
 	currentHeader := b.Header()
 
 	// Write the data
 	if header.ParentHash == currentHeader.Hash {
 		// Fast path to save the new canonical header
-		return b.writeCanonicalHeader(evnt, header)
+		return b.writeCanonicalHeader(batch, evnt, header)
 	}
 
-	if err := b.db.WriteHeader(header); err != nil {
+	if err := batch.WriteHeader(header); err != nil {
 		return err
 	}
 
@@ -1217,7 +1250,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	}
 
 	// Write the difficulty
-	if err := b.db.WriteTotalDifficulty(
+	if err := batch.WriteTotalDifficulty(
 		header.Hash,
 		big.NewInt(0).Add(
 			parentTD,
@@ -1233,7 +1266,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	incomingTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(header.Difficulty))
 	if incomingTD.Cmp(currentTD) > 0 {
 		// new block has higher difficulty, reorg the chain
-		if err := b.handleReorg(evnt, currentHeader, header); err != nil {
+		if err := b.handleReorg(batch, evnt, currentHeader, header); err != nil {
 			return err
 		}
 	} else {
@@ -1241,7 +1274,7 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 		evnt.AddOldHeader(header)
 		evnt.Type = EventFork
 
-		if err := b.writeFork(header); err != nil {
+		if err := b.writeFork(batch, header); err != nil {
 			return err
 		}
 	}
@@ -1249,8 +1282,22 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	return nil
 }
 
-// writeFork writes the new header forks to the DB
-func (b *Blockchain) writeFork(header *types.Header) error {
+// commitHeader commits the batch with the written header,
+// and updates the head of the chain if the header has become the new head
+func (b *Blockchain) commitHeader(batch storage.Batch, evnt *Event, header *types.Header) error {
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	if evnt.Type == EventHead || evnt.Type == EventReorg {
+		b.setCurrentHeader(header, evnt.Difficulty)
+	}
+
+	return nil
+}
+
+// writeFork writes the new header forks to the batch
+func (b *Blockchain) writeFork(batch storage.Batch, header *types.Header) error {
 	forks, err := b.db.ReadForks()
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -1269,15 +1316,16 @@ func (b *Blockchain) writeFork(header *types.Header) error {
 	}
 
 	newForks = append(newForks, header.Hash)
-	if err := b.db.WriteForks(newForks); err != nil {
+	if err := batch.WriteForks(newForks); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// handleReorg handles a reorganization event
+// handleReorg handles a reorganization event, writing the new canonical chain to the batch
 func (b *Blockchain) handleReorg(
+	batch storage.Batch,
 	evnt *Event,
 	oldHeader *types.Header,
 	newHeader *types.Header,
@@ -1335,18 +1383,18 @@ func (b *Blockchain) handleReorg(
 		evnt.AddNewHeader(b)
 	}
 
-	if err := b.writeFork(oldChainHead); err != nil {
+	if err := b.writeFork(batch, oldChainHead); err != nil {
 		return fmt.Errorf("failed to write the old header as fork: %w", err)
 	}
 
 	// Update canonical chain numbers
 	for _, h := range newChain {
-		if err := b.db.WriteCanonicalHash(h.Number, h.Hash); err != nil {
+		if err := batch.WriteCanonicalHash(h.Number, h.Hash); err != nil {
 			return err
 		}
 	}
 
-	diff, err := b.advanceHead(newChainHead)
+	diff, err := b.writeHead(batch, newChainHead)
 	if err != nil {
 		return err
 	}
@@ -1408,6 +1456,8 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 
 // Close closes the DB connection
 func (b *Blockchain) Close() error {
+	b.bloomIndexer.close()
+
 	return b.db.Close()
 }
 
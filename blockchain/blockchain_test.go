@@ -594,7 +594,7 @@ func TestBlockchainWriteBody(t *testing.T) {
 
 		assert.NoError(
 			t,
-			chain.writeBody(block),
+			chain.writeBody(chain.db.NewBatch(), block),
 		)
 	})
 
@@ -623,7 +623,7 @@ func TestBlockchainWriteBody(t *testing.T) {
 		assert.ErrorIs(
 			t,
 			errRecoveryAddressFailed,
-			chain.writeBody(block),
+			chain.writeBody(chain.db.NewBatch(), block),
 		)
 	})
 
@@ -651,7 +651,10 @@ func TestBlockchainWriteBody(t *testing.T) {
 
 		chain := newChain(t, txFromByTxHash)
 
-		assert.NoError(t, chain.writeBody(block))
+		batch := chain.db.NewBatch()
+
+		assert.NoError(t, chain.writeBody(batch, block))
+		assert.NoError(t, batch.Write())
 
 		readBody, ok := chain.readBody(block.Hash())
 		assert.True(t, ok)
@@ -886,7 +889,13 @@ func TestBlockchainReadBody(t *testing.T) {
 
 	txFromByTxHash[tx.Hash] = types.ZeroAddress
 
-	if err := b.writeBody(block); err != nil {
+	batch := b.db.NewBatch()
+
+	if err := b.writeBody(batch, block); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := batch.Write(); err != nil {
 		t.Fatal(err)
 	}
 
@@ -966,69 +975,7 @@ func TestCalculateGasLimit(t *testing.T) {
 	}
 }
 
-// TestGasPriceAverage tests the average gas price of the
-// blockchain
-func TestGasPriceAverage(t *testing.T) {
-	testTable := []struct {
-		name               string
-		previousAverage    *big.Int
-		previousCount      *big.Int
-		newValues          []*big.Int
-		expectedNewAverage *big.Int
-	}{
-		{
-			"no previous average data",
-			big.NewInt(0),
-			big.NewInt(0),
-			[]*big.Int{
-				big.NewInt(1),
-				big.NewInt(2),
-				big.NewInt(3),
-				big.NewInt(4),
-				big.NewInt(5),
-			},
-			big.NewInt(3),
-		},
-		{
-			"previous average data",
-			// For example (5 + 5 + 5 + 5 + 5) / 5
-			big.NewInt(5),
-			big.NewInt(5),
-			[]*big.Int{
-				big.NewInt(1),
-				big.NewInt(2),
-				big.NewInt(3),
-			},
-			// (5 * 5 + 1 + 2 + 3) / 8
-			big.NewInt(3),
-		},
-	}
-
-	for _, testCase := range testTable {
-		t.Run(testCase.name, func(t *testing.T) {
-			// Setup the mock data
-			blockchain := NewTestBlockchain(t, nil)
-			blockchain.gpAverage.price = testCase.previousAverage
-			blockchain.gpAverage.count = testCase.previousCount
-
-			// Update the average gas price
-			blockchain.updateGasPriceAvg(testCase.newValues)
-
-			// Make sure the average gas price count is correct
-			assert.Equal(
-				t,
-				int64(len(testCase.newValues))+testCase.previousCount.Int64(),
-				blockchain.gpAverage.count.Int64(),
-			)
-
-			// Make sure the average gas price is correct
-			assert.Equal(t, testCase.expectedNewAverage.String(), blockchain.gpAverage.price.String())
-		})
-	}
-}
 
-// TestBlockchain_VerifyBlockParent verifies that parent block verification
-// errors are handled correctly
 func TestBlockchain_VerifyBlockParent(t *testing.T) {
 	t.Parallel()
 
@@ -1391,3 +1338,148 @@ func TestBlockchain_CalculateBaseFee(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockchain_RepairHead(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	config := &chain.Chain{
+		Genesis: &chain.Genesis{},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}
+
+	newChain := func() *Blockchain {
+		b, err := NewBlockchain(hclog.NewNullLogger(), db, config, &MockVerifier{}, &mockExecutor{}, &mockSigner{})
+		assert.NoError(t, err)
+		assert.NoError(t, b.ComputeGenesis())
+
+		return b
+	}
+
+	b := newChain()
+
+	headers := AppendNewTestHeaders([]*types.Header{b.Header()}, 4)
+	for _, h := range headers[1:4] {
+		assert.NoError(t, b.WriteFullBlock(&types.FullBlock{
+			Block:    &types.Block{Header: h},
+			Receipts: []*types.Receipt{},
+		}, "test"))
+	}
+
+	assert.NoError(t, b.Close())
+
+	// the head has been advanced, but the receipts haven't been written
+	assert.NoError(t, db.WriteCanonicalHeader(headers[4], big.NewInt(10)))
+
+	b = newChain()
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+	assert.NoError(t, b.Close())
+
+	head, ok := db.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, headers[3].Hash, head)
+
+	headNumber, ok := db.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), headNumber)
+
+	// the head header is missing
+	assert.NoError(t, db.WriteHeadHash(types.StringToHash("1")))
+
+	b = newChain()
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+	assert.NoError(t, b.Close())
+}
+
+// failingBatchStorage is a storage whose batches fail to commit
+type failingBatchStorage struct {
+	storage.Storage
+}
+
+func (s *failingBatchStorage) NewBatch() storage.Batch {
+	return &failingBatch{Batch: s.Storage.NewBatch()}
+}
+
+type failingBatch struct {
+	storage.Batch
+}
+
+func (b *failingBatch) Write() error {
+	return errors.New("write failed")
+}
+
+func TestBlockchain_WriteFullBlockAtomic(t *testing.T) {
+	t.Parallel()
+
+	b := TestBlockchain(t, nil)
+	genesis := b.Header()
+
+	b.db = &failingBatchStorage{Storage: b.db}
+
+	headers := AppendNewTestHeaders([]*types.Header{genesis}, 1)
+	block := &types.Block{Header: headers[1]}
+
+	assert.Error(t, b.WriteFullBlock(&types.FullBlock{Block: block, Receipts: []*types.Receipt{}}, "test"))
+
+	// nothing has been written
+	assert.Equal(t, genesis.Hash, b.Header().Hash)
+
+	_, err := b.db.ReadHeader(block.Hash())
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, ok := b.db.ReadCanonicalHash(1)
+	assert.False(t, ok)
+
+	head, ok := b.db.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, genesis.Hash, head)
+}
+
+func TestBlockchain_RegenerateState(t *testing.T) {
+	t.Parallel()
+
+	errUnableToExecute := errors.New("unable to execute transactions")
+
+	parent := &types.Header{
+		Hash:      types.StringToHash("1"),
+		StateRoot: types.StringToHash("2"),
+	}
+
+	storageCallback := func(storage *storage.MockStorage) {
+		storage.HookReadHeader(func(hash types.Hash) (*types.Header, error) {
+			return parent, nil
+		})
+	}
+
+	executorCallback := func(executor *mockExecutor) {
+		executor.HookProcessBlock(func(
+			root types.Hash,
+			block *types.Block,
+			address types.Address,
+		) (*state.Transition, error) {
+			// the block is executed on top of the parent state
+			assert.Equal(t, parent.StateRoot, root)
+
+			return nil, errUnableToExecute
+		})
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback:  storageCallback,
+		ExecutorCallback: executorCallback,
+	})
+	assert.NoError(t, err)
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number:     1,
+			ParentHash: parent.Hash,
+		},
+	}
+
+	assert.ErrorIs(t, blockchain.RegenerateState(block), errUnableToExecute)
+}
@@ -0,0 +1,307 @@
+package blockchain
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// BloomBitsSectionSize is the number of blocks in a section of the bloom bits index
+	BloomBitsSectionSize = 4096
+
+	// bloomBitsConfirmations is the number of blocks a section has to be behind the head
+	// before it is indexed, so the index is not affected by reorgs
+	bloomBitsConfirmations = 256
+
+	// bloomBitsCount is the number of bits in a logs bloom
+	bloomBitsCount = types.BloomByteLength * 8
+)
+
+// bloomIndexer maintains the bloom bits index.
+// The logs blooms of the blocks are rotated per section,
+// so for every bloom bit there is a bit vector with the bit of every block in the section.
+// Matching a value in a section then takes reading only the vectors of the 3 bits it sets.
+// The vectors with no bits set are not stored
+type bloomIndexer struct {
+	logger hclog.Logger
+	db     storage.Storage
+
+	sections atomic.Uint64 // number of the indexed sections
+	running  atomic.Bool   // flag indicating if the sections are being indexed
+
+	wg      sync.WaitGroup
+	closeCh chan struct{}
+}
+
+func newBloomIndexer(logger hclog.Logger, db storage.Storage) *bloomIndexer {
+	i := &bloomIndexer{
+		logger:  logger.Named("bloombits"),
+		db:      db,
+		closeCh: make(chan struct{}),
+	}
+
+	if sections, ok := db.ReadBloomSections(); ok {
+		i.sections.Store(sections)
+	}
+
+	return i
+}
+
+// readySections returns the number of the sections which can be indexed for the head
+func readySections(head uint64) uint64 {
+	if head+1 < bloomBitsConfirmations {
+		return 0
+	}
+
+	return (head + 1 - bloomBitsConfirmations) / BloomBitsSectionSize
+}
+
+// notify indexes the sections completed by the new head in the background.
+// The sections of an existing database are backfilled the same way
+func (i *bloomIndexer) notify(head uint64) {
+	if readySections(head) <= i.sections.Load() {
+		return
+	}
+
+	if !i.running.CompareAndSwap(false, true) {
+		// the sections are being indexed, the next head picks up the rest
+		return
+	}
+
+	i.wg.Add(1)
+
+	go func() {
+		defer i.wg.Done()
+		defer i.running.Store(false)
+
+		i.indexSections(readySections(head))
+	}()
+}
+
+// indexSections indexes the sections up to the given number
+func (i *bloomIndexer) indexSections(ready uint64) {
+	for section := i.sections.Load(); section < ready; section++ {
+		select {
+		case <-i.closeCh:
+			return
+		default:
+		}
+
+		if err := i.indexSection(section); err != nil {
+			i.logger.Error("failed to index the bloom bits section", "section", section, "err", err)
+
+			return
+		}
+
+		i.sections.Store(section + 1)
+
+		i.logger.Debug("indexed the bloom bits section", "section", section)
+	}
+}
+
+// indexSection rotates the logs blooms of the blocks in the section and writes them
+func (i *bloomIndexer) indexSection(section uint64) error {
+	vectors := make([][]byte, bloomBitsCount)
+
+	for j := uint64(0); j < BloomBitsSectionSize; j++ {
+		number := section*BloomBitsSectionSize + j
+
+		hash, ok := i.db.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", number)
+		}
+
+		header, err := i.db.ReadHeader(hash)
+		if err != nil {
+			return fmt.Errorf("unable to read header of block %d, %w", number, err)
+		}
+
+		if header.LogsBloom == (types.Bloom{}) {
+			// no logs in the block
+			continue
+		}
+
+		for bit := uint(0); bit < bloomBitsCount; bit++ {
+			if !header.LogsBloom.IsBitSet(bit) {
+				continue
+			}
+
+			if vectors[bit] == nil {
+				vectors[bit] = make([]byte, BloomBitsSectionSize/8)
+			}
+
+			vectors[bit][j/8] |= 1 << (j % 8)
+		}
+	}
+
+	batch := i.db.NewBatch()
+
+	for bit, vector := range vectors {
+		if vector == nil {
+			continue
+		}
+
+		if err := batch.WriteBloomBits(uint(bit), section, vector); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.WriteBloomSections(section + 1); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// matchSection returns the bit vector of the blocks in the section
+// whose logs blooms possibly match every group of the filter
+func (i *bloomIndexer) matchSection(section uint64, groups [][][3]uint) []byte {
+	vectors := make(map[uint][]byte)
+
+	getVector := func(bit uint) []byte {
+		vector, ok := vectors[bit]
+		if !ok {
+			if vector, ok = i.db.ReadBloomBits(bit, section); !ok {
+				// no block in the section sets the bit
+				vector = make([]byte, BloomBitsSectionSize/8)
+			}
+
+			vectors[bit] = vector
+		}
+
+		return vector
+	}
+
+	result := filledVector()
+
+	for _, group := range groups {
+		groupResult := make([]byte, BloomBitsSectionSize/8)
+
+		for _, bits := range group {
+			valueResult := filledVector()
+
+			for _, bit := range bits {
+				andVector(valueResult, getVector(bit))
+			}
+
+			orVector(groupResult, valueResult)
+		}
+
+		andVector(result, groupResult)
+	}
+
+	return result
+}
+
+// close stops the indexing and waits for it to finish
+func (i *bloomIndexer) close() {
+	close(i.closeCh)
+	i.wg.Wait()
+}
+
+func filledVector() []byte {
+	vector := make([]byte, BloomBitsSectionSize/8)
+	for k := range vector {
+		vector[k] = 0xff
+	}
+
+	return vector
+}
+
+func andVector(dst, src []byte) {
+	for k := range dst {
+		dst[k] &= src[k]
+	}
+}
+
+func orVector(dst, src []byte) {
+	for k := range dst {
+		dst[k] |= src[k]
+	}
+}
+
+// bloomMatches checks if the logs bloom possibly matches every group of the filter
+func bloomMatches(bloom types.Bloom, groups [][][3]uint) bool {
+	for _, group := range groups {
+		match := false
+
+		for _, bits := range group {
+			if bloom.IsBitSet(bits[0]) && bloom.IsBitSet(bits[1]) && bloom.IsBitSet(bits[2]) {
+				match = true
+
+				break
+			}
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterBlocksByBloom returns the numbers of the blocks in the range [from, to]
+// which possibly contain logs matching the filter. The filter is a list of groups,
+// a block matches if its logs bloom possibly contains a value of every group.
+// Empty groups match any block.
+// The indexed sections are matched using the bloom bits index,
+// the rest of the blocks using the logs blooms of the headers
+func (b *Blockchain) FilterBlocksByBloom(from, to uint64, filter [][][]byte) []uint64 {
+	groups := make([][][3]uint, 0, len(filter))
+
+	for _, values := range filter {
+		if len(values) == 0 {
+			continue
+		}
+
+		group := make([][3]uint, len(values))
+		for k, value := range values {
+			group[k] = types.BloomBitIndexes(value)
+		}
+
+		groups = append(groups, group)
+	}
+
+	numbers := make([]uint64, 0)
+
+	if len(groups) == 0 {
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+
+		return numbers
+	}
+
+	number := from
+
+	for indexed := b.bloomIndexer.sections.Load() * BloomBitsSectionSize; number <= to && number < indexed; {
+		section := number / BloomBitsSectionSize
+		result := b.bloomIndexer.matchSection(section, groups)
+
+		for ; number <= to && number/BloomBitsSectionSize == section; number++ {
+			j := number % BloomBitsSectionSize
+			if result[j/8]&(1<<(j%8)) != 0 {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+
+	for ; number <= to; number++ {
+		header, ok := b.GetHeaderByNumber(number)
+		if !ok {
+			break
+		}
+
+		if bloomMatches(header.LogsBloom, groups) {
+			numbers = append(numbers, number)
+		}
+	}
+
+	return numbers
+}
@@ -0,0 +1,154 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadySections(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint64(0), readySections(0))
+	assert.Equal(t, uint64(0), readySections(BloomBitsSectionSize+bloomBitsConfirmations-2))
+	assert.Equal(t, uint64(1), readySections(BloomBitsSectionSize+bloomBitsConfirmations-1))
+	assert.Equal(t, uint64(2), readySections(2*BloomBitsSectionSize+bloomBitsConfirmations-1))
+}
+
+func TestBlockchain_FilterBlocksByBloom(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1")
+		addr2  = types.StringToAddress("2")
+		topic1 = types.StringToHash("1")
+	)
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	blooms := map[uint64]types.Bloom{
+		5: types.CreateBloom([]*types.Receipt{
+			{Logs: []*types.Log{{Address: addr1, Topics: []types.Hash{topic1}}}},
+		}),
+		7: types.CreateBloom([]*types.Receipt{
+			{Logs: []*types.Log{{Address: addr2}}},
+		}),
+		// after the indexed section
+		BloomBitsSectionSize + 4: types.CreateBloom([]*types.Receipt{
+			{Logs: []*types.Log{{Address: addr1, Topics: []types.Hash{topic1}}}},
+		}),
+	}
+
+	head := uint64(BloomBitsSectionSize + 10)
+	writeBloomHeaders(t, db, head, blooms)
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), db, &chain.Chain{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	// the section is complete, but not confirmed yet
+	b.bloomIndexer.notify(head)
+	b.bloomIndexer.wg.Wait()
+	assert.Equal(t, uint64(0), b.bloomIndexer.sections.Load())
+
+	b.bloomIndexer.indexSections(1)
+	assert.Equal(t, uint64(1), b.bloomIndexer.sections.Load())
+
+	sections, ok := db.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), sections)
+
+	testTable := []struct {
+		name     string
+		from     uint64
+		to       uint64
+		filter   [][][]byte
+		expected []uint64
+	}{
+		{
+			"address",
+			0,
+			head,
+			[][][]byte{{addr1.Bytes()}},
+			[]uint64{5, BloomBitsSectionSize + 4},
+		},
+		{
+			"any of the addresses",
+			0,
+			head,
+			[][][]byte{{addr1.Bytes(), addr2.Bytes()}},
+			[]uint64{5, 7, BloomBitsSectionSize + 4},
+		},
+		{
+			"address and topic",
+			0,
+			head,
+			[][][]byte{{addr2.Bytes()}, {topic1.Bytes()}},
+			[]uint64{},
+		},
+		{
+			"range",
+			6,
+			BloomBitsSectionSize + 4,
+			[][][]byte{{addr1.Bytes(), addr2.Bytes()}, {}},
+			[]uint64{7, BloomBitsSectionSize + 4},
+		},
+		{
+			"empty filter",
+			1,
+			3,
+			[][][]byte{{}},
+			[]uint64{1, 2, 3},
+		},
+		{
+			"beyond the head",
+			head - 1,
+			head + 10,
+			[][][]byte{{addr1.Bytes()}},
+			[]uint64{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(
+				t,
+				testCase.expected,
+				b.FilterBlocksByBloom(testCase.from, testCase.to, testCase.filter),
+			)
+		})
+	}
+
+	require.NoError(t, b.Close())
+}
+
+// writeBloomHeaders writes the canonical headers up to the head with the given logs blooms
+func writeBloomHeaders(t *testing.T, db storage.Storage, head uint64, blooms map[uint64]types.Bloom) {
+	t.Helper()
+
+	parentHash := types.ZeroHash
+
+	for number := uint64(0); number <= head; number++ {
+		header := &types.Header{
+			Number:     number,
+			ParentHash: parentHash,
+			LogsBloom:  blooms[number],
+			ExtraData:  []byte{},
+		}
+		header.ComputeHash()
+
+		require.NoError(t, db.WriteCanonicalHeader(header, big.NewInt(int64(number))))
+
+		parentHash = header.Hash
+	}
+}
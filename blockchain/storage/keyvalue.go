@@ -39,13 +39,17 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// BLOOM_BITS is the prefix for the bloom bits index
+	BLOOM_BITS = []byte("B")
 )
 
 // Sub-prefixes
 var (
-	HASH   = []byte("hash")
-	NUMBER = []byte("number")
-	EMPTY  = []byte("empty")
+	HASH     = []byte("hash")
+	NUMBER   = []byte("number")
+	EMPTY    = []byte("empty")
+	SECTIONS = []byte("sections")
 )
 
 // KV is a key value storage interface.
@@ -55,6 +59,13 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	NewBatch() KVBatch
+}
+
+// KVBatch is a set of key-value writes which are committed atomically
+type KVBatch interface {
+	Set(p []byte, v []byte)
+	Write() error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -68,6 +79,44 @@ func NewKeyValueStorage(logger hclog.Logger, db KV) Storage {
 	return &KeyValueStorage{logger: logger, db: db}
 }
 
+// NewBatch creates a batch of writes, which are committed atomically
+func (s *KeyValueStorage) NewBatch() Batch {
+	batch := s.db.NewBatch()
+
+	return &keyValueBatch{
+		KeyValueStorage: &KeyValueStorage{
+			logger: s.logger,
+			db:     &batchKV{KV: s.db, batch: batch},
+		},
+		batch: batch,
+	}
+}
+
+// keyValueBatch is a key-value storage whose writes are added to a batch
+type keyValueBatch struct {
+	*KeyValueStorage
+
+	batch KVBatch
+}
+
+// Write commits the batch
+func (b *keyValueBatch) Write() error {
+	return b.batch.Write()
+}
+
+// batchKV directs the writes of a kv database to a batch
+type batchKV struct {
+	KV
+
+	batch KVBatch
+}
+
+func (b *batchKV) Set(p []byte, v []byte) error {
+	b.batch.Set(p, v)
+
+	return nil
+}
+
 func (s *KeyValueStorage) encodeUint(n uint64) []byte {
 	b := make([]byte, 8)
 	binary.BigEndian.PutUint64(b[:], n)
@@ -267,6 +316,41 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// BLOOM BITS //
+
+func (s *KeyValueStorage) bloomBitsKey(bit uint, section uint64) []byte {
+	key := make([]byte, 10)
+	binary.BigEndian.PutUint16(key[:2], uint16(bit))
+	binary.BigEndian.PutUint64(key[2:], section)
+
+	return key
+}
+
+// WriteBloomBits writes the bit vector of the bloom bit in the section
+func (s *KeyValueStorage) WriteBloomBits(bit uint, section uint64, bits []byte) error {
+	return s.set(BLOOM_BITS, s.bloomBitsKey(bit, section), bits)
+}
+
+// ReadBloomBits reads the bit vector of the bloom bit in the section
+func (s *KeyValueStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	return s.get(BLOOM_BITS, s.bloomBitsKey(bit, section))
+}
+
+// WriteBloomSections writes the number of the sections in the bloom bits index
+func (s *KeyValueStorage) WriteBloomSections(sections uint64) error {
+	return s.set(BLOOM_BITS, SECTIONS, s.encodeUint(sections))
+}
+
+// ReadBloomSections reads the number of the sections in the bloom bits index
+func (s *KeyValueStorage) ReadBloomSections() (uint64, bool) {
+	data, ok := s.get(BLOOM_BITS, SECTIONS)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
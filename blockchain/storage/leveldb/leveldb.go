@@ -59,7 +59,31 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// NewBatch creates a batch of writes, which are committed atomically
+func (l *levelDBKV) NewBatch() storage.KVBatch {
+	return &levelDBBatch{
+		db:    l.db,
+		batch: new(leveldb.Batch),
+	}
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
 }
+
+// levelDBBatch is the leveldb implementation of the kv batch
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+// Set adds the key-value pair to the batch
+func (b *levelDBBatch) Set(p []byte, v []byte) {
+	b.batch.Put(p, v)
+}
+
+// Write commits the batch to leveldb storage
+func (b *levelDBBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
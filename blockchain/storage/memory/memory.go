@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"sync"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/hashicorp/go-hclog"
@@ -8,23 +10,30 @@ import (
 
 // NewMemoryStorage creates the new storage reference with inmemory
 func NewMemoryStorage(logger hclog.Logger) (storage.Storage, error) {
-	db := &memoryKV{map[string][]byte{}}
+	db := &memoryKV{db: map[string][]byte{}}
 
 	return storage.NewKeyValueStorage(logger, db), nil
 }
 
 // memoryKV is an in memory implementation of the kv storage
 type memoryKV struct {
-	db map[string][]byte
+	lock sync.RWMutex
+	db   map[string][]byte
 }
 
 func (m *memoryKV) Set(p []byte, v []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	m.db[hex.EncodeToHex(p)] = v
 
 	return nil
 }
 
 func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	v, ok := m.db[hex.EncodeToHex(p)]
 	if !ok {
 		return nil, false, nil
@@ -33,6 +42,34 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) NewBatch() storage.KVBatch {
+	return &memoryBatch{
+		kv:     m,
+		writes: make(map[string][]byte),
+	}
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
+
+// memoryBatch is the in memory implementation of the kv batch
+type memoryBatch struct {
+	kv     *memoryKV
+	writes map[string][]byte
+}
+
+func (b *memoryBatch) Set(p []byte, v []byte) {
+	b.writes[hex.EncodeToHex(p)] = v
+}
+
+func (b *memoryBatch) Write() error {
+	b.kv.lock.Lock()
+	defer b.kv.lock.Unlock()
+
+	for k, v := range b.writes {
+		b.kv.db[k] = v
+	}
+
+	return nil
+}
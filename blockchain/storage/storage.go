@@ -37,8 +37,44 @@ type Storage interface {
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	WriteBloomBits(bit uint, section uint64, bits []byte) error
+	ReadBloomBits(bit uint, section uint64) ([]byte, bool)
+	WriteBloomSections(sections uint64) error
+	ReadBloomSections() (uint64, bool)
+
+	NewBatch() Batch
+
 	Close() error
 }
 
+// Batch is a set of storage writes which are committed atomically.
+// The writes are not visible to the reads until the batch is committed
+type Batch interface {
+	WriteCanonicalHash(n uint64, hash types.Hash) error
+
+	WriteHeadHash(h types.Hash) error
+	WriteHeadNumber(uint64) error
+
+	WriteForks(forks []types.Hash) error
+
+	WriteTotalDifficulty(hash types.Hash, diff *big.Int) error
+
+	WriteHeader(h *types.Header) error
+
+	WriteCanonicalHeader(h *types.Header, diff *big.Int) error
+
+	WriteBody(hash types.Hash, body *types.Body) error
+
+	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
+
+	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
+
+	WriteBloomBits(bit uint, section uint64, bits []byte) error
+	WriteBloomSections(sections uint64) error
+
+	// Write commits the batch
+	Write() error
+}
+
 // Factory is a factory method to create a blockchain storage
 type Factory func(config map[string]interface{}, logger hclog.Logger) (Storage, error)
@@ -48,6 +48,12 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testBloomBits", func(t *testing.T) {
+		testBloomBits(t, m)
+	})
+	t.Run("testBatch", func(t *testing.T) {
+		testBatch(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -389,6 +395,79 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testBloomBits(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBloomSections()
+	assert.False(t, ok)
+
+	bits := []byte{0x1, 0x2, 0x3}
+
+	assert.NoError(t, s.WriteBloomBits(2047, 1, bits))
+	assert.NoError(t, s.WriteBloomSections(2))
+
+	found, ok := s.ReadBloomBits(2047, 1)
+	assert.True(t, ok)
+	assert.Equal(t, bits, found)
+
+	// the vectors of the other bits and sections are separate
+	_, ok = s.ReadBloomBits(2047, 0)
+	assert.False(t, ok)
+
+	_, ok = s.ReadBloomBits(2046, 1)
+	assert.False(t, ok)
+
+	sections, ok := s.ReadBloomSections()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), sections)
+}
+
+func testBatch(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	h := &types.Header{
+		Number:    5,
+		ExtraData: []byte{0x1},
+	}
+	h.ComputeHash()
+
+	batch := s.NewBatch()
+
+	assert.NoError(t, batch.WriteCanonicalHeader(h, big.NewInt(5)))
+	assert.NoError(t, batch.WriteReceipts(h.Hash, []*types.Receipt{}))
+
+	// the writes are not visible before the batch is committed
+	_, ok := s.ReadHeadHash()
+	assert.False(t, ok)
+
+	_, err := s.ReadHeader(h.Hash)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, batch.Write())
+
+	headHash, ok := s.ReadHeadHash()
+	assert.True(t, ok)
+	assert.Equal(t, h.Hash, headHash)
+
+	header, err := s.ReadHeader(h.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, h, header)
+
+	td, ok := s.ReadTotalDifficulty(h.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(5), td)
+
+	receipts, err := s.ReadReceipts(h.Hash)
+	assert.NoError(t, err)
+	assert.Empty(t, receipts)
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -465,6 +544,10 @@ type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type writeBloomBitsDelegate func(uint, uint64, []byte) error
+type readBloomBitsDelegate func(uint, uint64) ([]byte, bool)
+type writeBloomSectionsDelegate func(uint64) error
+type readBloomSectionsDelegate func() (uint64, bool)
 type closeDelegate func() error
 
 type MockStorage struct {
@@ -487,6 +570,10 @@ type MockStorage struct {
 	readReceiptsFn         readReceiptsDelegate
 	writeTxLookupFn        writeTxLookupDelegate
 	readTxLookupFn         readTxLookupDelegate
+	writeBloomBitsFn       writeBloomBitsDelegate
+	readBloomBitsFn        readBloomBitsDelegate
+	writeBloomSectionsFn   writeBloomSectionsDelegate
+	readBloomSectionsFn    readBloomSectionsDelegate
 	closeFn                closeDelegate
 }
 
@@ -722,6 +809,60 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) WriteBloomBits(bit uint, section uint64, bits []byte) error {
+	if m.writeBloomBitsFn != nil {
+		return m.writeBloomBitsFn(bit, section, bits)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomBits(fn writeBloomBitsDelegate) {
+	m.writeBloomBitsFn = fn
+}
+
+func (m *MockStorage) ReadBloomBits(bit uint, section uint64) ([]byte, bool) {
+	if m.readBloomBitsFn != nil {
+		return m.readBloomBitsFn(bit, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBloomBits(fn readBloomBitsDelegate) {
+	m.readBloomBitsFn = fn
+}
+
+func (m *MockStorage) WriteBloomSections(sections uint64) error {
+	if m.writeBloomSectionsFn != nil {
+		return m.writeBloomSectionsFn(sections)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBloomSections(fn writeBloomSectionsDelegate) {
+	m.writeBloomSectionsFn = fn
+}
+
+func (m *MockStorage) ReadBloomSections() (uint64, bool) {
+	if m.readBloomSectionsFn != nil {
+		return m.readBloomSectionsFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadBloomSections(fn readBloomSectionsDelegate) {
+	m.readBloomSectionsFn = fn
+}
+
+// NewBatch returns a batch which writes to the mock storage directly,
+// so the write hooks are called for the batch writes as well
+func (m *MockStorage) NewBatch() Batch {
+	return &mockBatch{m}
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
@@ -733,3 +874,11 @@ func (m *MockStorage) Close() error {
 func (m *MockStorage) HookClose(fn closeDelegate) {
 	m.closeFn = fn
 }
+
+type mockBatch struct {
+	*MockStorage
+}
+
+func (b *mockBatch) Write() error {
+	return nil
+}
@@ -3,7 +3,6 @@ package blockchain
 import (
 	"errors"
 	"fmt"
-	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
@@ -200,16 +199,13 @@ func NewMockBlockchain(
 	}
 
 	blockchain := &Blockchain{
-		logger:    hclog.NewNullLogger(),
-		db:        mockStorage,
-		consensus: mockVerifier,
-		executor:  executor,
-		config:    config,
-		stream:    &eventStream{},
-		gpAverage: &gasPriceAverage{
-			price: big.NewInt(0),
-			count: big.NewInt(0),
-		},
+		logger:       hclog.NewNullLogger(),
+		db:           mockStorage,
+		consensus:    mockVerifier,
+		executor:     executor,
+		config:       config,
+		stream:       &eventStream{},
+		bloomIndexer: newBloomIndexer(hclog.NewNullLogger(), mockStorage),
 	}
 
 	if err := blockchain.initCaches(10); err != nil {
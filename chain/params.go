@@ -87,7 +87,10 @@ type Forks struct {
 	Constantinople *Fork `json:"constantinople,omitempty"`
 	Petersburg     *Fork `json:"petersburg,omitempty"`
 	Istanbul       *Fork `json:"istanbul,omitempty"`
+	Berlin         *Fork `json:"berlin,omitempty"`
 	London         *Fork `json:"london,omitempty"`
+	Shanghai       *Fork `json:"shanghai,omitempty"`
+	Cancun         *Fork `json:"cancun,omitempty"`
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
@@ -117,10 +120,22 @@ func (f *Forks) IsPetersburg(block uint64) bool {
 	return f.active(f.Petersburg, block)
 }
 
+func (f *Forks) IsBerlin(block uint64) bool {
+	return f.active(f.Berlin, block)
+}
+
 func (f *Forks) IsLondon(block uint64) bool {
 	return f.active(f.London, block)
 }
 
+func (f *Forks) IsShanghai(block uint64) bool {
+	return f.active(f.Shanghai, block)
+}
+
+func (f *Forks) IsCancun(block uint64) bool {
+	return f.active(f.Cancun, block)
+}
+
 func (f *Forks) IsEIP150(block uint64) bool {
 	return f.active(f.EIP150, block)
 }
@@ -140,7 +155,10 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Constantinople: f.active(f.Constantinople, block),
 		Petersburg:     f.active(f.Petersburg, block),
 		Istanbul:       f.active(f.Istanbul, block),
+		Berlin:         f.active(f.Berlin, block),
 		London:         f.active(f.London, block),
+		Shanghai:       f.active(f.Shanghai, block),
+		Cancun:         f.active(f.Cancun, block),
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
@@ -169,7 +187,10 @@ type ForksInTime struct {
 	Constantinople,
 	Petersburg,
 	Istanbul,
+	Berlin,
 	London,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
 	EIP155 bool
@@ -184,5 +205,8 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	Berlin:         NewFork(0),
 	London:         NewFork(0),
+	Shanghai:       NewFork(0),
+	Cancun:         NewFork(0),
 }
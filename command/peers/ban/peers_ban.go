@@ -0,0 +1,50 @@
+package ban
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersBanCmd := &cobra.Command{
+		Use:   "ban",
+		Short: "Disconnects the specified peer and rejects its connections for the given duration",
+		Run:   runCommand,
+	}
+
+	setFlags(peersBanCmd)
+	helper.SetRequiredFlags(peersBanCmd, params.getRequiredFlags())
+
+	return peersBanCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.peerID,
+		peerIDFlag,
+		"",
+		"libp2p node ID of a specific peer within p2p network",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.duration,
+		durationFlag,
+		network.DefaultBanDuration,
+		"the duration of the ban",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.banPeer(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,55 @@
+package banned
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type BannedPeer struct {
+	ID    string `json:"id"`
+	Until string `json:"until"`
+}
+
+type PeersBannedResult struct {
+	Peers []BannedPeer `json:"peers"`
+}
+
+func newPeersBannedResult(peers []*proto.BannedPeer) *PeersBannedResult {
+	resultPeers := make([]BannedPeer, len(peers))
+	for i, p := range peers {
+		resultPeers[i] = BannedPeer{
+			ID:    p.Id,
+			Until: time.Unix(p.Until, 0).UTC().Format(time.RFC3339),
+		}
+	}
+
+	return &PeersBannedResult{
+		Peers: resultPeers,
+	}
+}
+
+func (r *PeersBannedResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BANNED PEERS]\n")
+
+	if len(r.Peers) == 0 {
+		buffer.WriteString("No banned peers found")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of banned peers: %d\n\n", len(r.Peers)))
+
+		rows := make([]string, len(r.Peers))
+		for i, p := range r.Peers {
+			rows[i] = fmt.Sprintf("%s|banned until %s", p.ID, p.Until)
+		}
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
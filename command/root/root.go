@@ -20,6 +20,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/rootchain"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/version"
@@ -63,6 +64,7 @@ func (rc *RootCommand) registerSubCommands() {
 		polybft.GetCommand(),
 		bridge.GetCommand(),
 		regenesis.GetCommand(),
+		state.GetCommand(),
 	)
 }
 
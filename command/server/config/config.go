@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/network"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
@@ -33,6 +34,11 @@ type Config struct {
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+
+	StatePruning       bool   `json:"state_pruning" yaml:"state_pruning"`
+	StateFlushInterval uint64 `json:"state_flush_interval" yaml:"state_flush_interval"`
+	StateRetain        uint64 `json:"state_retain" yaml:"state_retain"`
+	StateSnapshot      bool   `json:"state_snapshot" yaml:"state_snapshot"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -49,6 +55,12 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	StaticPeers       []string `json:"static_peers" yaml:"static_peers"`
+	TrustedPeers      []string `json:"trusted_peers" yaml:"trusted_peers"`
+	RestrictDiscovery bool     `json:"restrict_discovery" yaml:"restrict_discovery"`
+	PrivatePeers      []string `json:"private_peers" yaml:"private_peers"`
+	PrivateMode       bool     `json:"private_mode" yaml:"private_mode"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -56,6 +68,10 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceBump          uint64 `json:"price_bump" yaml:"price_bump"`
+	MaxAccountPromoted uint64 `json:"max_account_promoted" yaml:"max_account_promoted"`
+	PeerTxRateLimit    uint64 `json:"peer_tx_rate_limit" yaml:"peer_tx_rate_limit"`
+	RPCTxRateLimit     uint64 `json:"rpc_tx_rate_limit" yaml:"rpc_tx_rate_limit"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -97,6 +113,11 @@ func DefaultConfig() *Config {
 				defaultNetworkConfig.Addr.IP,
 				defaultNetworkConfig.Addr.Port,
 			),
+			StaticPeers:       []string{},
+			TrustedPeers:      []string{},
+			RestrictDiscovery: false,
+			PrivatePeers:      []string{},
+			PrivateMode:       false,
 		},
 		Telemetry:  &Telemetry{},
 		ShouldSeal: true,
@@ -104,6 +125,10 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			PriceBump:          10,
+			MaxAccountPromoted: 0,
+			PeerTxRateLimit:    0,
+			RPCTxRateLimit:     0,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -115,6 +140,10 @@ func DefaultConfig() *Config {
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		StatePruning:             false,
+		StateFlushInterval:       itrie.DefaultPruningFlushInterval,
+		StateRetain:              itrie.DefaultPruningRetain,
+		StateSnapshot:            false,
 	}
 }
 
@@ -53,6 +53,10 @@ func (p *serverParams) initRawParams() error {
 		p.initDevMode()
 	}
 
+	if err := p.initStatePruning(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -61,6 +65,18 @@ func (p *serverParams) initRawParams() error {
 	return p.initAddresses()
 }
 
+func (p *serverParams) initStatePruning() error {
+	if !p.rawConfig.StatePruning {
+		return nil
+	}
+
+	if p.rawConfig.StateFlushInterval == 0 || p.rawConfig.StateRetain == 0 {
+		return errInvalidStatePruning
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -25,11 +26,20 @@ const (
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	restrictDiscoveryFlag        = "restrict-discovery"
+	privatePeersFlag             = "private-peers"
+	privateModeFlag              = "private-mode"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	priceBumpFlag                = "price-bump"
+	maxPromotedFlag              = "max-promoted"
+	peerTxRateLimitFlag          = "peer-tx-rate-limit"
+	rpcTxRateLimitFlag           = "rpc-tx-rate-limit"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -40,6 +50,11 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+
+	statePruningFlag       = "state-pruning"
+	stateFlushIntervalFlag = "state-flush-interval"
+	stateRetainFlag        = "state-retain"
+	stateSnapshotFlag      = "state-snapshot"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -63,7 +78,8 @@ var (
 )
 
 var (
-	errInvalidNATAddress = errors.New("could not parse NAT IP address")
+	errInvalidNATAddress   = errors.New("could not parse NAT IP address")
+	errInvalidStatePruning = errors.New("state flush interval and retained states have to be greater than 0")
 )
 
 type serverParams struct {
@@ -170,12 +186,22 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
+
+			StaticPeers:       p.rawConfig.Network.StaticPeers,
+			TrustedPeers:      p.rawConfig.Network.TrustedPeers,
+			RestrictDiscovery: p.rawConfig.Network.RestrictDiscovery,
+			PrivatePeers:      p.rawConfig.Network.PrivatePeers,
+			PrivateMode:       p.rawConfig.Network.PrivateMode,
 		},
 		DataDir:            p.rawConfig.DataDir,
 		Seal:               p.rawConfig.ShouldSeal,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		PriceBump:          p.rawConfig.TxPool.PriceBump,
+		MaxAccountPromoted: p.rawConfig.TxPool.MaxAccountPromoted,
+		PeerTxRateLimit:    p.rawConfig.TxPool.PeerTxRateLimit,
+		RPCTxRateLimit:     p.rawConfig.TxPool.RPCTxRateLimit,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
@@ -184,5 +210,18 @@ func (p *serverParams) generateConfig() *server.Config {
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		StatePruning:          p.getStatePruningConfig(),
+		StateSnapshot:         p.rawConfig.StateSnapshot,
+	}
+}
+
+func (p *serverParams) getStatePruningConfig() *itrie.PruningConfig {
+	if !p.rawConfig.StatePruning {
+		return nil
+	}
+
+	return &itrie.PruningConfig{
+		FlushInterval: p.rawConfig.StateFlushInterval,
+		Retain:        p.rawConfig.StateRetain,
 	}
 }
@@ -161,6 +161,41 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		defaultConfig.Network.StaticPeers,
+		"the multiaddrs of the peers the client always stays connected to, bypassing the peer limits",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		defaultConfig.Network.TrustedPeers,
+		"the libp2p IDs of the peers allowed to connect to the client regardless of the peer limits",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Network.RestrictDiscovery,
+		restrictDiscoveryFlag,
+		defaultConfig.Network.RestrictDiscovery,
+		"only connect to the static and trusted peers",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.PrivatePeers,
+		privatePeersFlag,
+		defaultConfig.Network.PrivatePeers,
+		"the libp2p IDs of the trusted peers which are never shared with other peers through the discovery",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Network.PrivateMode,
+		privateModeFlag,
+		defaultConfig.Network.PrivateMode,
+		"hide the client behind its static peers (sentries): disable the discovery and advertise no addresses",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -185,6 +220,34 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"minimum price increase (in percents) for replacing a transaction with the same nonce",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxAccountPromoted,
+		maxPromotedFlag,
+		defaultConfig.TxPool.MaxAccountPromoted,
+		"maximum slots the promoted transactions of an account can occupy (0 for unlimited)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PeerTxRateLimit,
+		peerTxRateLimitFlag,
+		defaultConfig.TxPool.PeerTxRateLimit,
+		"maximum number of transactions accepted from a gossip peer per second (0 for unlimited)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.RPCTxRateLimit,
+		rpcTxRateLimitFlag,
+		defaultConfig.TxPool.RPCTxRateLimit,
+		"maximum number of transactions accepted from a JSON-RPC client address per second (0 for unlimited)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.corsAllowedOrigins,
 		corsOriginFlag,
@@ -228,6 +291,34 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StatePruning,
+		statePruningFlag,
+		defaultConfig.StatePruning,
+		"prune the historical state instead of archiving it, only the latest states are kept queryable",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateFlushInterval,
+		stateFlushIntervalFlag,
+		defaultConfig.StateFlushInterval,
+		"the number of blocks between the flushes of the state to the disk when pruning the state",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateRetain,
+		stateRetainFlag,
+		defaultConfig.StateRetain,
+		"the number of the latest states kept queryable when pruning the state",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSnapshot,
+		stateSnapshotFlag,
+		defaultConfig.StateSnapshot,
+		"keep a flat snapshot of the latest states, so the accounts and the storage are read without walking the trie",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -0,0 +1,380 @@
+package prune
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	blockFlag   = "block"
+	dryRunFlag  = "dry-run"
+)
+
+const (
+	blockchainDir = "blockchain"
+	trieDir       = "trie"
+
+	// prunedTrieDir is the directory the reachable state is copied to
+	prunedTrieDir = "trie.pruned"
+
+	// prunedRootFile marks the copy of the state as complete, it holds the copied state root
+	prunedRootFile = "trie.pruned.root"
+
+	// prunedProgressFile marks the copy of the state as in progress, it holds the state root being copied
+	prunedProgressFile = "trie.pruned.progress"
+
+	// oldTrieDir is the directory the state is moved to while it is replaced by the pruned state
+	oldTrieDir = "trie.old"
+)
+
+var (
+	params = &pruneParams{}
+)
+
+var (
+	errDecodeBlock    = errors.New("unable to decode block value")
+	errHeadNotFound   = errors.New("head of the chain not found")
+	errBlockAboveHead = errors.New("block is above the head of the chain")
+	errBlockBelowHead = errors.New("block is below the head of the chain, the head can't be rewound")
+	errStateNotFound  = errors.New("state of the block not found")
+	errInvalidState   = errors.New("state root of the copied state doesn't match")
+	errResumeDryRun   = errors.New("the interrupted pruning has to be resumed before a dry run")
+)
+
+type pruneParams struct {
+	dataDir  string
+	blockRaw string
+	dryRun   bool
+
+	block *uint64
+}
+
+func (p *pruneParams) validateFlags() error {
+	if p.blockRaw != "" {
+		block, err := types.ParseUint64orHex(&p.blockRaw)
+		if err != nil {
+			return errDecodeBlock
+		}
+
+		p.block = &block
+	}
+
+	return nil
+}
+
+func (p *pruneParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// prune copies the state reachable from the state root of the block to a new database,
+// and replaces the state database with it. Every step is resumed by running it again
+func (p *pruneParams) prune() (*PruneResult, error) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-prune",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	var (
+		triePath       = filepath.Join(p.dataDir, trieDir)
+		prunedPath     = filepath.Join(p.dataDir, prunedTrieDir)
+		prunedRootPath = filepath.Join(p.dataDir, prunedRootFile)
+		progressPath   = filepath.Join(p.dataDir, prunedProgressFile)
+		oldPath        = filepath.Join(p.dataDir, oldTrieDir)
+	)
+
+	if _, err := os.Stat(oldPath); err == nil {
+		// interrupted while replacing the state database
+		if p.dryRun {
+			return nil, errResumeDryRun
+		}
+
+		sizeBefore, err := dirSize(oldPath)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info("Resuming the replacement of the state database")
+
+		if err := replaceState(triePath, prunedPath, prunedRootPath, oldPath); err != nil {
+			return nil, err
+		}
+
+		sizeAfter, err := dirSize(triePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PruneResult{Resumed: true, SizeBefore: sizeBefore, SizeAfter: sizeAfter}, nil
+	}
+
+	chain, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, blockchainDir), logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the blockchain database, is the node stopped: %w", err)
+	}
+	defer chain.Close()
+
+	header, err := p.getHeader(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{
+		DryRun:    p.dryRun,
+		Block:     header.Number,
+		StateRoot: header.StateRoot.String(),
+	}
+
+	if result.SizeBefore, err = dirSize(triePath); err != nil {
+		return nil, err
+	}
+
+	trieDB, err := goleveldb.OpenFile(triePath, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the state database, is the node stopped: %w", err)
+	}
+
+	trieStorage := itrie.NewKV(trieDB)
+
+	if _, ok, err := itrie.GetNode(header.StateRoot.Bytes(), trieStorage); err != nil || !ok {
+		trieDB.Close()
+
+		return nil, errStateNotFound
+	}
+
+	if p.dryRun {
+		counter := newSizeCounter()
+		err := itrie.CopyTrieResumable(header.StateRoot.Bytes(), trieStorage, counter, nil, false)
+
+		trieDB.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.SizeAfter = counter.size
+
+		return result, nil
+	}
+
+	err = copyState(prunedPath, prunedRootPath, progressPath, trieStorage, header.StateRoot, logger)
+
+	trieDB.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := replaceState(triePath, prunedPath, prunedRootPath, oldPath); err != nil {
+		return nil, err
+	}
+
+	if result.SizeAfter, err = dirSize(triePath); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getHeader returns the header of the block whose state is kept, which has to be the head.
+// The blocks above a lower block can't be removed, as the finalized blocks
+// of the BFT consensus can't be produced again
+func (p *pruneParams) getHeader(chain storage.Storage) (*types.Header, error) {
+	headHash, ok := chain.ReadHeadHash()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	head, err := chain.ReadHeader(headHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the head: %w", err)
+	}
+
+	head.Hash = headHash
+
+	if p.block != nil && *p.block > head.Number {
+		return nil, errBlockAboveHead
+	}
+
+	if p.block != nil && *p.block < head.Number {
+		return nil, errBlockBelowHead
+	}
+
+	return head, nil
+}
+
+// copyState copies the state reachable from the root to the pruned database and verifies it.
+// A complete copy of the same root is reused, and an interrupted copy of the same root
+// is resumed, as the subtrees already in the pruned database are skipped
+func copyState(
+	prunedPath, prunedRootPath, progressPath string,
+	trieStorage itrie.Storage,
+	root types.Hash,
+	logger hclog.Logger,
+) error {
+	if copied, err := os.ReadFile(prunedRootPath); err == nil && string(copied) == root.String() {
+		logger.Info("Reusing the copied state", "root", root)
+
+		return nil
+	}
+
+	if copying, err := os.ReadFile(progressPath); err == nil && string(copying) == root.String() {
+		logger.Info("Resuming the copy of the state", "root", root)
+	} else {
+		// the copy of another root would leave its nodes in the pruned database
+		if err := os.RemoveAll(prunedPath); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(progressPath, []byte(root.String()), 0600); err != nil {
+			return err
+		}
+
+		logger.Info("Copying the state", "root", root)
+	}
+
+	prunedDB, err := goleveldb.OpenFile(prunedPath, nil)
+	if err != nil {
+		return err
+	}
+	defer prunedDB.Close()
+
+	prunedStorage := itrie.NewKV(prunedDB)
+
+	if err := itrie.CopyTrieResumable(root.Bytes(), trieStorage, prunedStorage, nil, false); err != nil {
+		return fmt.Errorf("failed to copy the state: %w", err)
+	}
+
+	logger.Info("Verifying the copied state", "root", root)
+
+	checkedRoot, err := itrie.HashChecker(root.Bytes(), prunedStorage)
+	if err != nil {
+		return fmt.Errorf("failed to verify the copied state: %w", err)
+	}
+
+	if checkedRoot != root {
+		return errInvalidState
+	}
+
+	if err := os.WriteFile(prunedRootPath, []byte(root.String()), 0600); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(progressPath)
+}
+
+// replaceState replaces the state database with the pruned one.
+// Every step is skipped if it has been done already, so an interrupted replacement is finished
+// by calling it again
+func replaceState(triePath, prunedPath, prunedRootPath, oldPath string) error {
+	if _, err := os.Stat(oldPath); errors.Is(err, os.ErrNotExist) {
+		if err := os.Rename(triePath, oldPath); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(prunedPath); err == nil {
+		if err := os.Rename(prunedPath, triePath); err != nil {
+			return err
+		}
+	}
+
+	// the old state database is the only one left
+	if _, err := os.Stat(triePath); err != nil {
+		return fmt.Errorf("pruned state database not found: %w", err)
+	}
+
+	if err := os.RemoveAll(oldPath); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(prunedRootPath)
+}
+
+// dirSize returns the size of the files in the directory
+func dirSize(path string) (int64, error) {
+	var size int64
+
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
+
+// sizeCounter is a storage which only counts the size of the written data.
+// The nodes and code shared by several parents are counted once
+type sizeCounter struct {
+	size int64
+
+	nodes map[string]struct{}
+	codes map[types.Hash]struct{}
+}
+
+func newSizeCounter() *sizeCounter {
+	return &sizeCounter{
+		nodes: make(map[string]struct{}),
+		codes: make(map[types.Hash]struct{}),
+	}
+}
+
+func (c *sizeCounter) Put(k, v []byte) {
+	if _, ok := c.nodes[string(k)]; ok {
+		return
+	}
+
+	c.nodes[string(k)] = struct{}{}
+	c.size += int64(len(k) + len(v))
+}
+
+// Get reports the counted nodes as present, so their subtrees aren't counted again
+func (c *sizeCounter) Get(k []byte) ([]byte, bool) {
+	_, ok := c.nodes[string(k)]
+
+	return nil, ok
+}
+
+func (c *sizeCounter) Batch() itrie.Batch {
+	return c
+}
+
+func (c *sizeCounter) Write() {}
+
+func (c *sizeCounter) SetCode(hash types.Hash, code []byte) {
+	if _, ok := c.codes[hash]; ok {
+		return
+	}
+
+	c.codes[hash] = struct{}{}
+	c.size += int64(types.HashLength + len(code))
+}
+
+func (c *sizeCounter) GetCode(hash types.Hash) ([]byte, bool) {
+	_, ok := c.codes[hash]
+
+	return nil, ok
+}
+
+func (c *sizeCounter) Close() error {
+	return nil
+}
@@ -0,0 +1,250 @@
+package prune
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
+)
+
+var pruneAddr = types.StringToAddress("1")
+
+// setupDataDir creates a data directory with a block for every nonce of the account
+func setupDataDir(t *testing.T, blocks uint64) (string, []types.Hash) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+
+	trieStorage, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	snap := itrie.NewState(trieStorage).NewSnapshot()
+	roots := make([]types.Hash, 0, blocks)
+
+	for number := uint64(0); number < blocks; number++ {
+		txn := state.NewTxn(snap)
+		txn.SetNonce(pruneAddr, number)
+		txn.SetCode(pruneAddr, []byte{byte(number)})
+
+		var root []byte
+
+		snap, root = snap.Commit(txn.Commit(false))
+		roots = append(roots, types.BytesToHash(root))
+	}
+
+	require.NoError(t, trieStorage.Close())
+
+	chain, err := leveldb.NewLevelDBStorage(filepath.Join(dataDir, blockchainDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	parentHash := types.ZeroHash
+
+	for number, root := range roots {
+		header := &types.Header{
+			Number:     uint64(number),
+			ParentHash: parentHash,
+			StateRoot:  root,
+			ExtraData:  []byte{},
+		}
+		header.ComputeHash()
+
+		require.NoError(t, chain.WriteCanonicalHeader(header, big.NewInt(int64(number))))
+
+		parentHash = header.Hash
+	}
+
+	require.NoError(t, chain.Close())
+
+	return dataDir, roots
+}
+
+// assertState checks if the state of the block is available in the data directory
+func assertState(t *testing.T, dataDir string, root types.Hash, number uint64, available bool) {
+	t.Helper()
+
+	trieStorage, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer trieStorage.Close()
+
+	snap, err := itrie.NewState(trieStorage).NewSnapshotAt(root)
+	if !available {
+		assert.Error(t, err)
+
+		return
+	}
+
+	require.NoError(t, err)
+
+	txn := state.NewTxn(snap)
+	assert.Equal(t, number, txn.GetNonce(pruneAddr))
+	assert.Equal(t, []byte{byte(number)}, txn.GetCode(pruneAddr))
+}
+
+func Test_prune(t *testing.T) {
+	t.Parallel()
+
+	dataDir, roots := setupDataDir(t, 3)
+	block := uint64(2)
+
+	// dry run
+	p := &pruneParams{dataDir: dataDir, block: &block, dryRun: true}
+
+	result, err := p.prune()
+	require.NoError(t, err)
+
+	assert.Less(t, result.SizeAfter, result.SizeBefore)
+	assertState(t, dataDir, roots[1], 1, true)
+
+	// prune
+	p.dryRun = false
+
+	result, err = p.prune()
+	require.NoError(t, err)
+
+	assert.Equal(t, block, result.Block)
+	assert.Equal(t, roots[2].String(), result.StateRoot)
+
+	assertState(t, dataDir, roots[2], 2, true)
+	assertState(t, dataDir, roots[0], 0, false)
+	assertState(t, dataDir, roots[1], 1, false)
+
+	for _, dir := range []string{prunedTrieDir, prunedRootFile, oldTrieDir} {
+		_, err := os.Stat(filepath.Join(dataDir, dir))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	}
+
+	// the head is kept
+	chain, err := leveldb.NewLevelDBStorage(filepath.Join(dataDir, blockchainDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	head, ok := chain.ReadHeadNumber()
+	assert.True(t, ok)
+	assert.Equal(t, block, head)
+
+	require.NoError(t, chain.Close())
+
+	// below the head
+	block = 1
+
+	_, err = p.prune()
+	assert.ErrorIs(t, err, errBlockBelowHead)
+
+	// above the head
+	block = 3
+
+	_, err = p.prune()
+	assert.ErrorIs(t, err, errBlockAboveHead)
+}
+
+func Test_prune_Resume(t *testing.T) {
+	t.Parallel()
+
+	dataDir, roots := setupDataDir(t, 2)
+	p := &pruneParams{dataDir: dataDir}
+
+	// interrupted after moving the state database
+	require.NoError(t, os.Rename(filepath.Join(dataDir, trieDir), filepath.Join(dataDir, oldTrieDir)))
+
+	_, err := p.prune()
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// the old state database is kept
+	_, err = os.Stat(filepath.Join(dataDir, oldTrieDir))
+	require.NoError(t, err)
+
+	require.NoError(t, os.Rename(filepath.Join(dataDir, oldTrieDir), filepath.Join(dataDir, trieDir)))
+
+	// interrupted after the copy of the state
+	trieDB, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, copyState(
+		filepath.Join(dataDir, prunedTrieDir),
+		filepath.Join(dataDir, prunedRootFile),
+		filepath.Join(dataDir, prunedProgressFile),
+		trieDB,
+		roots[1],
+		hclog.NewNullLogger(),
+	))
+	require.NoError(t, trieDB.Close())
+
+	require.NoError(t, os.Rename(filepath.Join(dataDir, trieDir), filepath.Join(dataDir, oldTrieDir)))
+
+	result, err := p.prune()
+	require.NoError(t, err)
+	assert.True(t, result.Resumed)
+
+	assertState(t, dataDir, roots[1], 1, true)
+	assertState(t, dataDir, roots[0], 0, false)
+}
+
+func Test_copyState_Resume(t *testing.T) {
+	t.Parallel()
+
+	dataDir, roots := setupDataDir(t, 2)
+
+	var (
+		prunedPath   = filepath.Join(dataDir, prunedTrieDir)
+		rootPath     = filepath.Join(dataDir, prunedRootFile)
+		progressPath = filepath.Join(dataDir, prunedProgressFile)
+	)
+
+	trieDB, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer trieDB.Close()
+
+	// interrupted right before the root node was written
+	prunedDB, err := goleveldb.OpenFile(prunedPath, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, itrie.CopyTrieResumable(roots[1].Bytes(), trieDB, itrie.NewKV(prunedDB), nil, false))
+	require.NoError(t, prunedDB.Delete(roots[1].Bytes(), nil))
+	require.NoError(t, prunedDB.Close())
+	require.NoError(t, os.WriteFile(progressPath, []byte(roots[1].String()), 0600))
+
+	require.NoError(t, copyState(prunedPath, rootPath, progressPath, trieDB, roots[1], hclog.NewNullLogger()))
+
+	copied, err := os.ReadFile(rootPath)
+	require.NoError(t, err)
+	assert.Equal(t, roots[1].String(), string(copied))
+
+	_, err = os.Stat(progressPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func Test_sizeCounter_Dedup(t *testing.T) {
+	t.Parallel()
+
+	dataDir, roots := setupDataDir(t, 1)
+
+	trieDB, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer trieDB.Close()
+
+	counter := newSizeCounter()
+
+	require.NoError(t, itrie.CopyTrieResumable(roots[0].Bytes(), trieDB, counter, nil, false))
+
+	size := counter.size
+	assert.Positive(t, size)
+
+	// the shared nodes and code are counted once
+	require.NoError(t, itrie.CopyTrieResumable(roots[0].Bytes(), trieDB, counter, nil, false))
+	assert.Equal(t, size, counter.size)
+
+	counter.SetCode(types.StringToHash("1"), []byte{0x1})
+	counter.SetCode(types.StringToHash("1"), []byte{0x1})
+	assert.Equal(t, size+int64(types.HashLength+1), counter.size)
+}
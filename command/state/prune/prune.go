@@ -0,0 +1,63 @@
+package prune
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	pruneCmd := &cobra.Command{
+		Use: "prune",
+		Short: "Removes the state which isn't reachable from the state root of the block. " +
+			"The node has to be stopped. An interrupted pruning is resumed by running the command again",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(pruneCmd)
+	helper.SetRequiredFlags(pruneCmd, params.getRequiredFlags())
+
+	return pruneCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the block whose state is kept, it has to be the head as the head isn't rewound (default: head)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.dryRun,
+		dryRunFlag,
+		false,
+		"only estimate the size of the state after pruning, without changing anything",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.prune()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,52 @@
+package prune
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PruneResult struct {
+	DryRun     bool   `json:"dryRun"`
+	Resumed    bool   `json:"resumed"`
+	Block      uint64 `json:"block"`
+	StateRoot  string `json:"stateRoot"`
+	SizeBefore int64  `json:"sizeBefore"`
+	SizeAfter  int64  `json:"sizeAfter"`
+}
+
+func (r *PruneResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	if r.DryRun {
+		buffer.WriteString("\n[STATE PRUNE DRY RUN]\n")
+	} else {
+		buffer.WriteString("\n[STATE PRUNE]\n")
+	}
+
+	vals := make([]string, 0, 5)
+
+	if r.Resumed && r.StateRoot == "" {
+		// only the replacement of the state database has been left
+		vals = append(vals, "Resumed|true")
+	} else {
+		vals = append(vals,
+			fmt.Sprintf("Block|%d", r.Block),
+			fmt.Sprintf("State Root|%s", r.StateRoot),
+		)
+	}
+
+	vals = append(vals, fmt.Sprintf("Size Before|%d bytes", r.SizeBefore))
+
+	if r.DryRun {
+		vals = append(vals, fmt.Sprintf("Estimated Size After|%d bytes", r.SizeAfter))
+	} else {
+		vals = append(vals, fmt.Sprintf("Size After|%d bytes", r.SizeAfter))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -35,7 +35,7 @@ func (i *backendIBFT) setupTransport() error {
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
+		func(obj interface{}, from peer.ID) {
 			if !i.isActiveValidator() {
 				return
 			}
@@ -47,6 +47,19 @@ func (i *backendIBFT) setupTransport() error {
 				return
 			}
 
+			if msg.View == nil {
+				i.network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, "message without view")
+
+				return
+			}
+
+			if _, err := i.recoverSender(msg); err != nil {
+				i.logger.Debug("invalid message signature", "peer", from, "err", err)
+				i.network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, err.Error())
+
+				return
+			}
+
 			i.consensus.AddMessage(msg)
 
 			i.logger.Debug(
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/0xPolygon/go-ibft/messages"
 	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
@@ -112,30 +113,9 @@ func (i *backendIBFT) IsValidProposal(rawProposal []byte) bool {
 }
 
 func (i *backendIBFT) IsValidValidator(msg *protoIBFT.Message) bool {
-	msgNoSig, err := msg.PayloadNoSig()
-	if err != nil {
-		return false
-	}
-
-	signerAddress, err := i.currentSigner.EcrecoverFromIBFTMessage(
-		msg.Signature,
-		msgNoSig,
-	)
-
+	signerAddress, err := i.recoverSender(msg)
 	if err != nil {
-		i.logger.Error("failed to ecrecover message", "err", err)
-
-		return false
-	}
-
-	// verify the signature came from the sender
-	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
-		i.logger.Error(
-			"signer address doesn't match with From",
-			"from", hex.EncodeToString(msg.From),
-			"signer", signerAddress,
-			"err", err,
-		)
+		i.logger.Error("invalid message signature", "err", err)
 
 		return false
 	}
@@ -158,6 +138,34 @@ func (i *backendIBFT) IsValidValidator(msg *protoIBFT.Message) bool {
 	return true
 }
 
+// recoverSender recovers the signer of the message
+// and makes sure it matches the sender in the From field
+func (i *backendIBFT) recoverSender(msg *protoIBFT.Message) (types.Address, error) {
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	signerAddress, err := i.currentSigner.EcrecoverFromIBFTMessage(
+		msg.Signature,
+		msgNoSig,
+	)
+	if err != nil {
+		return types.ZeroAddress, fmt.Errorf("failed to ecrecover message: %w", err)
+	}
+
+	// verify the signature came from the sender
+	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return types.ZeroAddress, fmt.Errorf(
+			"signer address %s doesn't match with From %s",
+			signerAddress,
+			hex.EncodeToString(msg.From),
+		)
+	}
+
+	return signerAddress, nil
+}
+
 func (i *backendIBFT) IsProposer(id []byte, height, round uint64) bool {
 	previousHeader, exists := i.blockchain.GetHeaderByNumber(height - 1)
 	if !exists {
@@ -381,27 +381,38 @@ func (f *fsm) Validate(proposal []byte) error {
 
 // ValidateSender validates sender address and signature
 func (f *fsm) ValidateSender(msg *proto.Message) error {
-	msgNoSig, err := msg.PayloadNoSig()
+	signerAddress, err := recoverSender(msg)
 	if err != nil {
 		return err
 	}
 
+	// verify the sender is in the active validator set
+	if !f.validators.Includes(signerAddress) {
+		return fmt.Errorf("signer address %s is not included in validator set", signerAddress.String())
+	}
+
+	return nil
+}
+
+// recoverSender recovers the signer of the message
+// and makes sure it matches the sender in the From field
+func recoverSender(msg *proto.Message) (types.Address, error) {
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
 	signerAddress, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
 	if err != nil {
-		return fmt.Errorf("failed to recover address from signature: %w", err)
+		return types.ZeroAddress, fmt.Errorf("failed to recover address from signature: %w", err)
 	}
 
 	// verify the signature came from the sender
 	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
-		return fmt.Errorf("signer address %s doesn't match From field", signerAddress.String())
+		return types.ZeroAddress, fmt.Errorf("signer address %s doesn't match From field", signerAddress.String())
 	}
 
-	// verify the sender is in the active validator set
-	if !f.validators.Includes(signerAddress) {
-		return fmt.Errorf("signer address %s is not included in validator set", signerAddress.String())
-	}
-
-	return nil
+	return signerAddress, nil
 }
 
 func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
@@ -5,6 +5,7 @@ import (
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -16,7 +17,7 @@ type BridgeTransport interface {
 
 // subscribeToIbftTopic subscribes to ibft topic
 func (p *Polybft) subscribeToIbftTopic() error {
-	return p.consensusTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+	return p.consensusTopic.Subscribe(func(obj interface{}, from peer.ID) {
 		if !p.runtime.isActiveValidator() {
 			return
 		}
@@ -28,6 +29,19 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		if msg.View == nil {
+			p.config.Network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, "message without view")
+
+			return
+		}
+
+		if _, err := recoverSender(msg); err != nil {
+			p.logger.Debug("consensus engine: invalid message signature", "peer", from, "err", err)
+			p.config.Network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, err.Error())
+
+			return
+		}
+
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -38,8 +38,12 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 		signer = NewFrontierSigner(forks.Homestead)
 	}
 
-	// London signer requires a fallback signer that is defined above.
-	// This is the reason why the london signer check is separated.
+	// Berlin and London signers require a fallback signer that is defined above.
+	// This is the reason why these signer checks are separated.
+	if forks.Berlin {
+		signer = NewBerlinSigner(chainID, forks.Homestead, signer)
+	}
+
 	if forks.London {
 		return NewLondonSigner(chainID, forks.Homestead, signer)
 	}
@@ -64,17 +68,17 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 // calcTxHash calculates the transaction hash (keccak256 hash of the RLP value)
 func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	a := signerPool.Get()
-	isDynamicFeeTx := tx.Type == types.DynamicFeeTx
+	isTypedTx := tx.Type == types.DynamicFeeTx || tx.Type == types.AccessListTx
 
 	v := a.NewArray()
 
-	if isDynamicFeeTx {
+	if isTypedTx {
 		v.Set(a.NewUint(chainID))
 	}
 
 	v.Set(a.NewUint(tx.Nonce))
 
-	if isDynamicFeeTx {
+	if tx.Type == types.DynamicFeeTx {
 		v.Set(a.NewBigInt(tx.GasTipCap))
 		v.Set(a.NewBigInt(tx.GasFeeCap))
 	} else {
@@ -93,8 +97,8 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 
 	v.Set(a.NewCopyBytes(tx.Input))
 
-	if isDynamicFeeTx {
-		v.Set(a.NewArray())
+	if isTypedTx {
+		v.Set(tx.AccessList.MarshalRLPWith(a))
 	} else {
 		// EIP155
 		if chainID != 0 {
@@ -105,7 +109,7 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	}
 
 	var hash []byte
-	if isDynamicFeeTx {
+	if isTypedTx {
 		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
 	} else {
 		hash = keccak.Keccak256Rlp(nil, v)
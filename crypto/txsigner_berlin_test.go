@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBerlinSignerSender(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+
+	testTable := []struct {
+		name   string
+		txType types.TxType
+	}{
+		{
+			"access list tx",
+			types.AccessListTx,
+		},
+		{
+			"legacy tx uses fallback signer",
+			types.LegacyTx,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateECDSAKey()
+			require.NoError(t, err)
+
+			txn := &types.Transaction{
+				Type:     testCase.txType,
+				To:       &toAddress,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(0),
+				AccessList: types.TxAccessList{
+					{
+						Address:     toAddress,
+						StorageKeys: []types.Hash{types.StringToHash("1")},
+					},
+				},
+			}
+
+			signer := NewBerlinSigner(100, true, NewEIP155Signer(100, true))
+
+			signedTx, err := signer.SignTx(txn, key)
+			require.NoError(t, err)
+
+			sender, err := signer.Sender(signedTx)
+			require.NoError(t, err)
+			require.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+
+			// the access list is part of the signed payload
+			if testCase.txType == types.AccessListTx {
+				signedTx.AccessList = nil
+
+				sender, err = signer.Sender(signedTx)
+				require.NoError(t, err)
+				require.NotEqual(t, PubKeyToAddress(&key.PublicKey), sender)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package gasprice
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	ErrInvalidPercentile = errors.New("invalid percentile")
+	ErrBlockCount        = errors.New("blockCount must be greater than 0")
+)
+
+// FeeHistoryReturn holds the fee history of a range of blocks
+type FeeHistoryReturn struct {
+	OldestBlock uint64
+	// BaseFeePerGas holds one entry more than the number of blocks,
+	// which is the base fee of the block following the newest one
+	BaseFeePerGas []uint64
+	GasUsedRatio  []float64
+	// Reward holds the requested tip percentiles of every block
+	Reward [][]*big.Int
+}
+
+// FeeHistory returns the fee history of blockCount blocks ending with newestBlock
+func (g *GasHelper) FeeHistory(
+	blockCount uint64,
+	newestBlock uint64,
+	rewardPercentiles []float64,
+) (*FeeHistoryReturn, error) {
+	if blockCount < 1 {
+		return nil, ErrBlockCount
+	}
+
+	if newestBlock > g.backend.Header().Number {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, newestBlock)
+	}
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("%w: %f", ErrInvalidPercentile, p)
+		}
+
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("%w: #%d:%f > #%d:%f", ErrInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	if blockCount > g.config.MaxHeaderHistory {
+		blockCount = g.config.MaxHeaderHistory
+	}
+
+	if blockCount > newestBlock+1 {
+		blockCount = newestBlock + 1
+	}
+
+	oldestBlock := newestBlock + 1 - blockCount
+
+	res := &FeeHistoryReturn{
+		OldestBlock:   oldestBlock,
+		BaseFeePerGas: make([]uint64, blockCount+1),
+		GasUsedRatio:  make([]float64, blockCount),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		res.Reward = make([][]*big.Int, blockCount)
+	}
+
+	for i := uint64(0); i < blockCount; i++ {
+		fees, err := g.processBlock(oldestBlock + i)
+		if err != nil {
+			return nil, err
+		}
+
+		res.BaseFeePerGas[i] = fees.header.BaseFee
+		res.GasUsedRatio[i] = fees.gasUsedRatio
+
+		if i == blockCount-1 {
+			res.BaseFeePerGas[i+1] = fees.nextBaseFee
+		}
+
+		if len(rewardPercentiles) > 0 {
+			res.Reward[i] = fees.rewards(rewardPercentiles)
+		}
+	}
+
+	return res, nil
+}
+
+// rewards returns the tips at the given percentiles of the gas used in the block
+func (f *blockFees) rewards(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+
+	if len(f.tips) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+
+		return rewards
+	}
+
+	totalGasUsed := uint64(0)
+	for _, tx := range f.tips {
+		totalGasUsed += tx.gasUsed
+	}
+
+	var (
+		txIndex    = 0
+		sumGasUsed = f.tips[0].gasUsed
+	)
+
+	for i, p := range percentiles {
+		threshold := float64(totalGasUsed) * p / 100
+
+		for float64(sumGasUsed) < threshold && txIndex < len(f.tips)-1 {
+			txIndex++
+			sumGasUsed += f.tips[txIndex].gasUsed
+		}
+
+		rewards[i] = new(big.Int).Set(f.tips[txIndex].tip)
+	}
+
+	return rewards
+}
@@ -0,0 +1,279 @@
+package gasprice
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+var (
+	// DefaultMaxPrice is the highest tip the oracle will ever suggest
+	DefaultMaxPrice = ethgo.Gwei(500)
+	// DefaultIgnorePrice is the tip below which transactions are not sampled
+	DefaultIgnorePrice = big.NewInt(2)
+	// DefaultLastPrice is the tip suggested when there are no samples yet
+	DefaultLastPrice = ethgo.Gwei(1)
+
+	// DefaultGasHelperConfig is the default configuration of the gas price oracle
+	DefaultGasHelperConfig = &Config{
+		NumOfBlocksToCheck: 20,
+		PricePercentile:    60,
+		SampleNumber:       3,
+		MaxPrice:           DefaultMaxPrice,
+		IgnorePrice:        DefaultIgnorePrice,
+		LastPrice:          DefaultLastPrice,
+		MaxHeaderHistory:   1024,
+		BlockCacheSize:     1024,
+	}
+
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// Config is the configuration of the gas price oracle
+type Config struct {
+	// NumOfBlocksToCheck is the number of latest blocks sampled for the suggested tip
+	NumOfBlocksToCheck uint64
+	// PricePercentile is the percentile of the sampled tips that is suggested
+	PricePercentile uint64
+	// SampleNumber is the number of the lowest tips sampled from each block
+	SampleNumber uint64
+	// MaxPrice is the upper bound of the suggested tip
+	MaxPrice *big.Int
+	// IgnorePrice is the lower bound of the sampled tips
+	IgnorePrice *big.Int
+	// LastPrice is the tip suggested before any block is sampled
+	LastPrice *big.Int
+	// MaxHeaderHistory is the maximum number of blocks returned by the fee history
+	MaxHeaderHistory uint64
+	// BlockCacheSize is the number of processed blocks kept in memory
+	BlockCacheSize int
+}
+
+// Blockchain is the interface of the chain data needed by the gas price oracle
+type Blockchain interface {
+	Header() *types.Header
+	GetHeaderByNumber(number uint64) (*types.Header, bool)
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	CalculateBaseFee(parent *types.Header) uint64
+}
+
+// GasStore is the interface of the gas price oracle used by the JSON-RPC endpoints
+type GasStore interface {
+	// MaxPriorityFeePerGas returns the suggested tip for a new transaction
+	MaxPriorityFeePerGas() (*big.Int, error)
+	// FeeHistory returns the base fees, gas usage and tip percentiles of a range of blocks
+	FeeHistory(blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (*FeeHistoryReturn, error)
+}
+
+var _ GasStore = (*GasHelper)(nil)
+
+// txTip is the effective tip of a single transaction along with the gas it used
+type txTip struct {
+	tip     *big.Int
+	gasUsed uint64
+}
+
+// blockFees holds the fee data of a processed block
+type blockFees struct {
+	header       *types.Header
+	nextBaseFee  uint64
+	gasUsedRatio float64
+	// tips are sorted in ascending order
+	tips []txTip
+}
+
+// GasHelper is the gas price oracle which samples the latest blocks
+// in order to suggest tips and report fee history
+type GasHelper struct {
+	config  *Config
+	backend Blockchain
+
+	// cache of the processed blocks, keyed by the block hash
+	blockCache *lru.Cache
+
+	lock           sync.Mutex
+	lastHeaderHash types.Hash
+	lastPrice      *big.Int
+}
+
+// NewGasHelper creates a new gas price oracle
+func NewGasHelper(config *Config, backend Blockchain) (*GasHelper, error) {
+	if config == nil {
+		return nil, errors.New("no config provided")
+	}
+
+	cache, err := lru.New(config.BlockCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the block cache: %w", err)
+	}
+
+	return &GasHelper{
+		config:     config,
+		backend:    backend,
+		blockCache: cache,
+		lastPrice:  new(big.Int).Set(config.LastPrice),
+	}, nil
+}
+
+// MaxPriorityFeePerGas suggests the tip for a new transaction, based on the
+// configured percentile of the lowest tips paid in the latest blocks
+func (g *GasHelper) MaxPriorityFeePerGas() (*big.Int, error) {
+	head := g.backend.Header()
+
+	g.lock.Lock()
+	lastHeaderHash, lastPrice := g.lastHeaderHash, g.lastPrice
+	g.lock.Unlock()
+
+	// the suggestion only changes when a new block arrives
+	if head.Hash == lastHeaderHash {
+		return new(big.Int).Set(lastPrice), nil
+	}
+
+	var (
+		samples = make([]*big.Int, 0, g.config.NumOfBlocksToCheck*g.config.SampleNumber)
+		checked = uint64(0)
+	)
+
+	for number := head.Number; number > 0 && checked < g.config.NumOfBlocksToCheck; number-- {
+		fees, err := g.processBlock(number)
+		if err != nil {
+			return nil, err
+		}
+
+		checked++
+
+		blockSamples := 0
+
+		for _, tx := range fees.tips {
+			if uint64(blockSamples) >= g.config.SampleNumber {
+				break
+			}
+
+			if tx.tip.Cmp(g.config.IgnorePrice) < 0 {
+				continue
+			}
+
+			samples = append(samples, tx.tip)
+			blockSamples++
+		}
+
+		// empty blocks signal that the network is not congested
+		if blockSamples == 0 {
+			samples = append(samples, lastPrice)
+		}
+	}
+
+	price := lastPrice
+
+	if len(samples) > 0 {
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].Cmp(samples[j]) < 0
+		})
+
+		price = samples[(len(samples)-1)*int(g.config.PricePercentile)/100]
+	}
+
+	if price.Cmp(g.config.MaxPrice) > 0 {
+		price = g.config.MaxPrice
+	}
+
+	price = new(big.Int).Set(price)
+
+	g.lock.Lock()
+	g.lastHeaderHash = head.Hash
+	g.lastPrice = price
+	g.lock.Unlock()
+
+	return new(big.Int).Set(price), nil
+}
+
+// processBlock returns the fee data of the block with the given number,
+// either from the cache or by processing the block and its receipts
+func (g *GasHelper) processBlock(number uint64) (*blockFees, error) {
+	// the cache is looked up by the canonical hash, so the body is read only on a miss
+	header, ok := g.backend.GetHeaderByNumber(number)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, number)
+	}
+
+	if cached, ok := g.blockCache.Get(header.Hash); ok {
+		return cached.(*blockFees), nil //nolint:forcetypeassert
+	}
+
+	block, ok := g.backend.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrBlockNotFound, number)
+	}
+
+	fees := &blockFees{
+		header:      header,
+		nextBaseFee: g.backend.CalculateBaseFee(header),
+	}
+
+	if header.GasLimit > 0 {
+		fees.gasUsedRatio = float64(header.GasUsed) / float64(header.GasLimit)
+	}
+
+	if len(block.Transactions) > 0 {
+		receipts, err := g.backend.GetReceiptsByHash(block.Hash())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(receipts) != len(block.Transactions) {
+			return nil, fmt.Errorf("receipts of block %d don't match its transactions", number)
+		}
+
+		var (
+			miner                 = types.BytesToAddress(header.Miner)
+			prevCumulativeGasUsed = uint64(0)
+		)
+
+		fees.tips = make([]txTip, 0, len(block.Transactions))
+
+		for i, tx := range block.Transactions {
+			gasUsed := receipts[i].CumulativeGasUsed - prevCumulativeGasUsed
+			prevCumulativeGasUsed = receipts[i].CumulativeGasUsed
+
+			// system transactions and the transactions of the block producer don't reflect the market
+			if tx.Type == types.StateTx || tx.From == miner {
+				continue
+			}
+
+			fees.tips = append(fees.tips, txTip{
+				tip:     effectiveTip(tx, header.BaseFee),
+				gasUsed: gasUsed,
+			})
+		}
+
+		sort.Slice(fees.tips, func(i, j int) bool {
+			return fees.tips[i].tip.Cmp(fees.tips[j].tip) < 0
+		})
+	}
+
+	g.blockCache.Add(header.Hash, fees)
+
+	return fees, nil
+}
+
+// effectiveTip returns the tip per gas paid to the block producer
+func effectiveTip(tx *types.Transaction, baseFee uint64) *big.Int {
+	if tx.Type == types.DynamicFeeTx {
+		return tx.EffectiveTip(baseFee)
+	}
+
+	tip := new(big.Int).Sub(tx.GasPrice, new(big.Int).SetUint64(baseFee))
+	if tip.Sign() < 0 {
+		return new(big.Int)
+	}
+
+	return tip
+}
@@ -0,0 +1,283 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+const testBaseFee = uint64(1000)
+
+var (
+	testSender = types.StringToAddress("1")
+	testMiner  = types.StringToAddress("2")
+)
+
+type mockBlockchain struct {
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+
+	// bodyReads is the number of the full blocks read
+	bodyReads int
+}
+
+func newMockBlockchain() *mockBlockchain {
+	m := &mockBlockchain{
+		receipts: make(map[types.Hash][]*types.Receipt),
+	}
+
+	// genesis
+	m.addBlock()
+
+	return m
+}
+
+// addBlock appends a block with the given transactions, each of them using 21000 gas
+func (m *mockBlockchain) addBlock(txs ...*types.Transaction) *types.Block {
+	number := uint64(len(m.blocks))
+	header := &types.Header{
+		Number:   number,
+		Miner:    testMiner.Bytes(),
+		BaseFee:  testBaseFee,
+		GasLimit: 21000 * 10,
+		GasUsed:  21000 * uint64(len(txs)),
+		Hash:     types.BytesToHash(big.NewInt(int64(number + 1)).Bytes()),
+	}
+
+	receipts := make([]*types.Receipt, len(txs))
+	for i := range txs {
+		receipts[i] = &types.Receipt{CumulativeGasUsed: 21000 * uint64(i+1)}
+	}
+
+	block := &types.Block{Header: header, Transactions: txs}
+
+	m.blocks = append(m.blocks, block)
+	m.receipts[header.Hash] = receipts
+
+	return block
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number].Header, true
+}
+
+func (m *mockBlockchain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	for _, block := range m.blocks {
+		if block.Hash() == hash {
+			m.bodyReads++
+
+			return block, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+func (m *mockBlockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee
+}
+
+func newDynamicTx(from types.Address, tip *big.Int) *types.Transaction {
+	return &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		From:      from,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(tip, new(big.Int).SetUint64(testBaseFee)),
+	}
+}
+
+func newLegacyTx(from types.Address, tip *big.Int) *types.Transaction {
+	return &types.Transaction{
+		Type:     types.LegacyTx,
+		From:     from,
+		GasPrice: new(big.Int).Add(tip, new(big.Int).SetUint64(testBaseFee)),
+	}
+}
+
+func TestGasHelper_MaxPriorityFeePerGas(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		setup    func(m *mockBlockchain)
+		expected *big.Int
+	}{
+		{
+			name:     "only genesis",
+			setup:    func(m *mockBlockchain) {},
+			expected: DefaultLastPrice,
+		},
+		{
+			name: "empty blocks",
+			setup: func(m *mockBlockchain) {
+				m.addBlock()
+				m.addBlock()
+			},
+			expected: DefaultLastPrice,
+		},
+		{
+			name: "percentile of the lowest tips",
+			setup: func(m *mockBlockchain) {
+				for i := 0; i < 3; i++ {
+					m.addBlock(
+						newDynamicTx(testSender, ethgo.Gwei(5)),
+						newLegacyTx(testSender, ethgo.Gwei(1)),
+						newDynamicTx(testSender, ethgo.Gwei(4)),
+						newLegacyTx(testSender, ethgo.Gwei(3)),
+						newDynamicTx(testSender, ethgo.Gwei(2)),
+					)
+				}
+			},
+			// samples are 1, 1, 1, 2, 2, 2, 3, 3, 3 gwei
+			expected: ethgo.Gwei(2),
+		},
+		{
+			name: "transactions of the block producer and state transactions are skipped",
+			setup: func(m *mockBlockchain) {
+				m.addBlock(
+					newDynamicTx(testMiner, ethgo.Gwei(1)),
+					&types.Transaction{Type: types.StateTx, From: testSender, GasPrice: big.NewInt(0)},
+					newDynamicTx(testSender, ethgo.Gwei(7)),
+				)
+			},
+			expected: ethgo.Gwei(7),
+		},
+		{
+			name: "tips below the ignore price are skipped",
+			setup: func(m *mockBlockchain) {
+				m.addBlock(
+					newLegacyTx(testSender, big.NewInt(1)),
+					newLegacyTx(testSender, big.NewInt(0)),
+				)
+			},
+			expected: DefaultLastPrice,
+		},
+		{
+			name: "tips are capped by the max price",
+			setup: func(m *mockBlockchain) {
+				m.addBlock(newDynamicTx(testSender, ethgo.Gwei(1000)))
+			},
+			expected: DefaultMaxPrice,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend := newMockBlockchain()
+			c.setup(backend)
+
+			helper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+			require.NoError(t, err)
+
+			price, err := helper.MaxPriorityFeePerGas()
+			require.NoError(t, err)
+			assert.Equal(t, c.expected.String(), price.String())
+		})
+	}
+}
+
+func TestGasHelper_MaxPriorityFeePerGas_Cached(t *testing.T) {
+	t.Parallel()
+
+	backend := newMockBlockchain()
+	backend.addBlock(newDynamicTx(testSender, ethgo.Gwei(3)))
+
+	helper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+	require.NoError(t, err)
+
+	price, err := helper.MaxPriorityFeePerGas()
+	require.NoError(t, err)
+	assert.Equal(t, ethgo.Gwei(3).String(), price.String())
+
+	// the returned value must not alias the cached one
+	price.SetUint64(0)
+
+	price, err = helper.MaxPriorityFeePerGas()
+	require.NoError(t, err)
+	assert.Equal(t, ethgo.Gwei(3).String(), price.String())
+
+	// the processed blocks aren't read again
+	bodyReads := backend.bodyReads
+
+	_, err = helper.FeeHistory(1, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bodyReads, backend.bodyReads)
+}
+
+func TestGasHelper_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	backend := newMockBlockchain()
+	backend.addBlock(
+		newDynamicTx(testSender, big.NewInt(3)),
+		newDynamicTx(testSender, big.NewInt(1)),
+		newDynamicTx(testSender, big.NewInt(2)),
+		newDynamicTx(testSender, big.NewInt(2)),
+	)
+	backend.addBlock()
+
+	helper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+	require.NoError(t, err)
+
+	history, err := helper.FeeHistory(10, 2, []float64{0, 50, 100})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(0), history.OldestBlock)
+	assert.Equal(t, []uint64{testBaseFee, testBaseFee, testBaseFee, testBaseFee}, history.BaseFeePerGas)
+	assert.Equal(t, []float64{0, 0.4, 0}, history.GasUsedRatio)
+	assert.Equal(t, [][]*big.Int{
+		{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+		{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+	}, history.Reward)
+
+	history, err = helper.FeeHistory(1, 1, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), history.OldestBlock)
+	assert.Len(t, history.BaseFeePerGas, 2)
+	assert.Len(t, history.GasUsedRatio, 1)
+	assert.Nil(t, history.Reward)
+}
+
+func TestGasHelper_FeeHistory_Errors(t *testing.T) {
+	t.Parallel()
+
+	backend := newMockBlockchain()
+	backend.addBlock()
+
+	helper, err := NewGasHelper(DefaultGasHelperConfig, backend)
+	require.NoError(t, err)
+
+	_, err = helper.FeeHistory(0, 1, nil)
+	assert.ErrorIs(t, err, ErrBlockCount)
+
+	_, err = helper.FeeHistory(1, 2, nil)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+
+	_, err = helper.FeeHistory(1, 1, []float64{101})
+	assert.ErrorIs(t, err, ErrInvalidPercentile)
+
+	_, err = helper.FeeHistory(1, 1, []float64{50, 10})
+	assert.ErrorIs(t, err, ErrInvalidPercentile)
+}
@@ -102,7 +102,12 @@ func getModifiedStorageMap(radix *state.Txn, address types.Address) map[types.Ha
 			return false
 		}
 
-		obj, _ := v.(*state.StateObject)
+		obj, ok := v.(*state.StateObject)
+		if !ok {
+			// Ignore the transaction scoped entries, such as the access list
+			return false
+		}
+
 		obj.Txn.Root().Walk(func(k []byte, v interface{}) bool {
 			val, _ := v.([]byte)
 			storageMap[types.BytesToHash(k)] = types.BytesToHash(val)
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -13,6 +14,18 @@ type Request struct {
 	ID     interface{}     `json:"id"`
 	Method string          `json:"method"`
 	Params json.RawMessage `json:"params,omitempty"`
+
+	// ctx is the context of the request, passed to the endpoints which accept it
+	ctx context.Context
+}
+
+// context returns the context of the request, the background context if it isn't set
+func (r Request) context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
+	return r.ctx
 }
 
 // Response is a jsonrpc response interface
@@ -2,19 +2,28 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
+
+	// built-in tracers selectable by name
+	_ "github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	_ "github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 )
 
 var (
 	defaultTraceTimeout = 5 * time.Second
 
+	// traceChainWorkers is the number of the blocks debug_traceChain traces in parallel
+	traceChainWorkers = runtime.NumCPU()
+
 	// ErrExecutionTimeout indicates the execution was terminated due to timeout
 	ErrExecutionTimeout = errors.New("execution timeout")
 	// ErrTraceGenesisBlock is an error returned when tracing genesis block which can't be traced
@@ -74,6 +83,10 @@ type TraceConfig struct {
 	DisableStorage   bool    `json:"disableStorage"`
 	EnableReturnData bool    `json:"enableReturnData"`
 	Timeout          *string `json:"timeout"`
+	// Tracer is the name of a registered tracer to use, the struct logger is used if empty
+	Tracer string `json:"tracer"`
+	// TracerConfig is the config of the selected tracer
+	TracerConfig json.RawMessage `json:"tracerConfig"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -166,12 +179,12 @@ func (d *Debug) TraceCall(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceCall(tx, header, tracer)
 }
 
@@ -184,12 +197,12 @@ func (d *Debug) traceBlock(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceBlock(block, tracer)
 }
 
@@ -198,6 +211,16 @@ func newTracer(config *TraceConfig) (
 	tracer.Tracer,
 	context.CancelFunc,
 	error,
+) {
+	return newTracerWithContext(context.Background(), config)
+}
+
+// newTracerWithContext creates new tracer by config,
+// the tracer is cancelled along with the given context
+func newTracerWithContext(ctx context.Context, config *TraceConfig) (
+	tracer.Tracer,
+	context.CancelFunc,
+	error,
 ) {
 	var (
 		timeout = defaultTraceTimeout
@@ -214,23 +237,160 @@ func newTracer(config *TraceConfig) (
 		}
 	}
 
-	tracer := structtracer.NewStructTracer(structtracer.Config{
-		EnableMemory:     config.EnableMemory,
-		EnableStack:      !config.DisableStack,
-		EnableStorage:    !config.DisableStorage,
-		EnableReturnData: config.EnableReturnData,
-	})
+	tracer, err := selectTracer(config)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 
 	go func() {
 		<-timeoutCtx.Done()
 
 		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
 			tracer.Cancel(ErrExecutionTimeout)
+		} else if ctx.Err() != nil {
+			tracer.Cancel(ctx.Err())
 		}
 	}()
 
 	// cancellation of context is done by caller
 	return tracer, cancel, nil
 }
+
+// selectTracer creates the tracer requested by config
+func selectTracer(config *TraceConfig) (tracer.Tracer, error) {
+	if config.Tracer == "" {
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			EnableStack:      !config.DisableStack,
+			EnableStorage:    !config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+	}
+
+	return tracer.New(config.Tracer, config.TracerConfig)
+}
+
+// traceChainResult is the trace of a single block streamed by debug_traceChain
+type traceChainResult struct {
+	Block  argUint64     `json:"block"`
+	Hash   types.Hash    `json:"hash"`
+	Traces []interface{} `json:"traces"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type traceChainJob struct {
+	number uint64
+	result chan *traceChainResult
+}
+
+// traceChain traces the blocks in the range (start, end] using parallel workers
+// and passes the results to notify in the order of the blocks.
+// It stops when notify fails or the context is cancelled
+func (d *Debug) traceChain(
+	ctx context.Context,
+	start, end uint64,
+	config *TraceConfig,
+	notify func(*traceChainResult) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := traceChainWorkers
+	if end-start < uint64(workers) {
+		workers = int(end - start)
+	}
+
+	var (
+		jobs = make(chan *traceChainJob)
+		// bounds the number of the results waiting to be sent
+		pending = make(chan *traceChainJob, 2*workers)
+	)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+
+		for num := start + 1; num <= end; num++ {
+			job := &traceChainJob{
+				number: num,
+				result: make(chan *traceChainResult, 1),
+			}
+
+			select {
+			case pending <- job:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- d.traceChainBlock(ctx, job.number, config)
+			}
+		}()
+	}
+
+	for job := range pending {
+		select {
+		case res := <-job.result:
+			if err := notify(res); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return ctx.Err()
+}
+
+// traceChainBlock traces all transactions of the block at the given height
+func (d *Debug) traceChainBlock(
+	ctx context.Context,
+	num uint64,
+	config *TraceConfig,
+) *traceChainResult {
+	res := &traceChainResult{
+		Block:  argUint64(num),
+		Traces: []interface{}{},
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		res.Error = fmt.Sprintf("block %d not found", num)
+
+		return res
+	}
+
+	res.Hash = block.Hash()
+
+	tracer, cancel, err := newTracerWithContext(ctx, config)
+	if err != nil {
+		res.Error = err.Error()
+
+		return res
+	}
+
+	defer cancel()
+
+	traces, err := d.store.TraceBlock(block, tracer)
+	if err != nil {
+		res.Error = err.Error()
+
+		return res
+	}
+
+	res.Traces = traces
+
+	return res
+}
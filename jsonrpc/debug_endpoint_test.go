@@ -1,15 +1,20 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type debugEndpointMockStore struct {
@@ -154,6 +159,16 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				Timeout:          &timeout15s,
 			},
 		},
+		{
+			input: `{
+				"tracer": "callTracer",
+				"tracerConfig": {"onlyTopCall": true}
+			}`,
+			expected: TraceConfig{
+				Tracer:       "callTracer",
+				TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -772,4 +787,134 @@ func Test_newTracer(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.NoError(t, err)
 	})
+
+	t.Run("should select tracer by name", func(t *testing.T) {
+		t.Parallel()
+
+		callTracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       calltracer.Name,
+			TracerConfig: json.RawMessage(`{"onlyTopCall": true, "withLog": true}`),
+		})
+		require.NoError(t, err)
+		cancel()
+
+		require.IsType(t, &calltracer.CallTracer{}, callTracer)
+		assert.Equal(
+			t,
+			calltracer.Config{OnlyTopCall: true, WithLog: true},
+			callTracer.(*calltracer.CallTracer).Config, //nolint:forcetypeassert
+		)
+
+		prestateTracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       prestatetracer.Name,
+			TracerConfig: json.RawMessage(`{"diffMode": true}`),
+		})
+		require.NoError(t, err)
+		cancel()
+
+		require.IsType(t, &prestatetracer.PrestateTracer{}, prestateTracer)
+		assert.True(t, prestateTracer.(*prestatetracer.PrestateTracer).Config.DiffMode) //nolint:forcetypeassert
+	})
+
+	t.Run("should return error for unknown tracer or invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := newTracer(&TraceConfig{Tracer: "fooTracer"})
+		assert.ErrorIs(t, err, tracer.ErrUnknownTracer)
+
+		_, _, err = newTracer(&TraceConfig{
+			Tracer:       calltracer.Name,
+			TracerConfig: json.RawMessage(`{"onlyTopCall": 1}`),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestTraceChain(t *testing.T) {
+	t.Parallel()
+
+	store := &debugEndpointMockStore{
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			if num == 5 {
+				return nil, false
+			}
+
+			return &types.Block{Header: &types.Header{Number: num}}, true
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			// finish the blocks out of order
+			time.Sleep(time.Duration(block.Number()%3) * time.Millisecond)
+
+			if block.Number() == 7 {
+				return nil, errors.New("trace failed")
+			}
+
+			return []interface{}{block.Number()}, nil
+		},
+	}
+
+	endpoint := &Debug{store}
+
+	t.Run("should stream the results in order", func(t *testing.T) {
+		t.Parallel()
+
+		results := make([]*traceChainResult, 0)
+
+		err := endpoint.traceChain(context.Background(), 1, 20, &TraceConfig{}, func(res *traceChainResult) error {
+			results = append(results, res)
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 19)
+
+		for i, res := range results {
+			num := uint64(i + 2)
+
+			assert.Equal(t, argUint64(num), res.Block)
+
+			switch num {
+			case 5:
+				assert.Equal(t, "block 5 not found", res.Error)
+			case 7:
+				assert.Equal(t, "trace failed", res.Error)
+				assert.Empty(t, res.Traces)
+			default:
+				assert.Empty(t, res.Error)
+				assert.Equal(t, []interface{}{num}, res.Traces)
+			}
+		}
+	})
+
+	t.Run("should stop when notify fails", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			notifyErr = errors.New("connection closed")
+			count     = 0
+		)
+
+		err := endpoint.traceChain(context.Background(), 0, 100, &TraceConfig{}, func(res *traceChainResult) error {
+			count++
+			if count == 3 {
+				return notifyErr
+			}
+
+			return nil
+		})
+		assert.ErrorIs(t, err, notifyErr)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("should stop when cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := endpoint.traceChain(ctx, 0, 100, &TraceConfig{}, func(res *traceChainResult) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
 }
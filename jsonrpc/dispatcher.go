@@ -2,14 +2,18 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strings"
+	"sync"
 	"unicode"
 
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -23,9 +27,16 @@ type funcData struct {
 	reqt  []reflect.Type
 	fv    reflect.Value
 	isDyn bool
+
+	// hasCtx indicates the first argument is the context of the request
+	hasCtx bool
 }
 
 func (f *funcData) numParams() int {
+	if f.hasCtx {
+		return f.inNum - 2
+	}
+
 	return f.inNum - 1
 }
 
@@ -46,9 +57,28 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
+	// running debug_traceChain subscriptions
+	traceChainsLock sync.Mutex
+	traceChains     map[string]*traceChainSubscription
+
 	params *dispatcherParams
 }
 
+// traceChainSubscription is a debug_traceChain stream of a web socket connection
+type traceChainSubscription struct {
+	ws     wsConn
+	cancel context.CancelFunc
+}
+
+const debugSubscriptionTemplate = `{
+	"jsonrpc": "2.0",
+	"method": "debug_subscription",
+	"params": {
+		"subscription":"%s",
+		"result": %s
+	}
+}`
+
 type dispatcherParams struct {
 	chainID   uint64
 	chainName string
@@ -64,8 +94,9 @@ func newDispatcher(
 	params *dispatcherParams,
 ) (*Dispatcher, error) {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:      logger.Named("dispatcher"),
+		params:      params,
+		traceChains: make(map[string]*traceChainSubscription),
 	}
 
 	if store != nil {
@@ -201,6 +232,14 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "newPendingTransactions" {
+		fullTx := false
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -223,19 +262,144 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 		return false, NewSubscriptionNotFoundError(filterID)
 	}
 
+	if d.cancelTraceChain(filterID) {
+		return true, nil
+	}
+
 	return d.filterManager.Uninstall(filterID), nil
 }
 
 func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
+
+	// stop tracing for the closed connection
+	d.traceChainsLock.Lock()
+	defer d.traceChainsLock.Unlock()
+
+	for id, sub := range d.traceChains {
+		if sub.ws == conn {
+			sub.cancel()
+			delete(d.traceChains, id)
+		}
+	}
+}
+
+// handleTraceChain validates the debug_traceChain request and registers the subscription.
+// It returns the subscription ID and the function streaming the traces to the connection
+func (d *Dispatcher) handleTraceChain(req Request, conn wsConn) (string, func(), Error) {
+	var params []json.RawMessage
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return "", nil, NewInvalidRequestError("Invalid json request")
+	}
+
+	if len(params) < 2 || len(params) > 3 {
+		return "", nil, NewInvalidParamsError("Invalid params")
+	}
+
+	var (
+		startNumber, endNumber BlockNumber
+		config                 = &TraceConfig{}
+	)
+
+	if err := json.Unmarshal(params[0], &startNumber); err != nil {
+		return "", nil, NewInvalidParamsError("Invalid start block")
+	}
+
+	if err := json.Unmarshal(params[1], &endNumber); err != nil {
+		return "", nil, NewInvalidParamsError("Invalid end block")
+	}
+
+	if len(params) == 3 && string(params[2]) != "null" {
+		if err := json.Unmarshal(params[2], config); err != nil {
+			return "", nil, NewInvalidParamsError("Invalid trace config")
+		}
+	}
+
+	debug := d.endpoints.Debug
+
+	start, err := GetNumericBlockNumber(startNumber, debug.store)
+	if err != nil {
+		return "", nil, NewInvalidParamsError(err.Error())
+	}
+
+	end, err := GetNumericBlockNumber(endNumber, debug.store)
+	if err != nil {
+		return "", nil, NewInvalidParamsError(err.Error())
+	}
+
+	if end <= start || end > debug.store.Header().Number {
+		return "", nil, NewInvalidParamsError(ErrIncorrectBlockRange.Error())
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if d.params.blockRangeLimit != 0 && end-start > d.params.blockRangeLimit {
+		return "", nil, NewInvalidParamsError(ErrBlockRangeTooHigh.Error())
+	}
+
+	// make sure the tracer can be created before subscribing
+	if _, err := selectTracer(config); err != nil {
+		return "", nil, NewInvalidParamsError(err.Error())
+	}
+
+	id := uuid.New().String()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d.traceChainsLock.Lock()
+	d.traceChains[id] = &traceChainSubscription{ws: conn, cancel: cancel}
+	d.traceChainsLock.Unlock()
+
+	stream := func() {
+		defer d.cancelTraceChain(id)
+
+		err := debug.traceChain(ctx, start, end, config, func(res *traceChainResult) error {
+			raw, err := json.Marshal(res)
+			if err != nil {
+				return err
+			}
+
+			return conn.WriteMessage(
+				websocket.TextMessage,
+				[]byte(fmt.Sprintf(debugSubscriptionTemplate, id, raw)),
+			)
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			d.logger.Warn(fmt.Sprintf("Subscription %s has been stopped, %v", id, err))
+		}
+	}
+
+	return id, stream, nil
+}
+
+// cancelTraceChain stops the debug_traceChain subscription with the given ID
+func (d *Dispatcher) cancelTraceChain(id string) bool {
+	d.traceChainsLock.Lock()
+	defer d.traceChainsLock.Unlock()
+
+	sub, ok := d.traceChains[id]
+	if !ok {
+		return false
+	}
+
+	sub.cancel()
+	delete(d.traceChains, id)
+
+	return true
 }
 
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	return d.HandleWsWithContext(context.Background(), reqBody, conn)
+}
+
+// HandleWsWithContext handles the request of the web socket connection,
+// the context is passed to the endpoints which accept it
+func (d *Dispatcher) HandleWsWithContext(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
+	req.ctx = ctx
+
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
@@ -253,6 +417,32 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return []byte(resp), nil
 	}
 
+	// debug_traceChain streams the traces of a block range to the connection
+	if req.Method == "debug_traceChain" {
+		subID, stream, err := d.handleTraceChain(req, conn)
+		if err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		resp, err := formatFilterResponse(req.ID, subID)
+		if err != nil {
+			d.cancelTraceChain(subID)
+
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		// the subscription ID has to reach the client before the traces
+		if writeErr := conn.WriteMessage(websocket.TextMessage, []byte(resp)); writeErr != nil {
+			d.cancelTraceChain(subID)
+
+			return nil, writeErr
+		}
+
+		go stream()
+
+		return nil, nil
+	}
+
 	if req.Method == "eth_unsubscribe" {
 		ok, err := d.handleUnsubscribe(req)
 		if err != nil {
@@ -282,6 +472,11 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 }
 
 func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return d.HandleWithContext(context.Background(), reqBody)
+}
+
+// HandleWithContext handles the request body, the context is passed to the endpoints which accept it
+func (d *Dispatcher) HandleWithContext(ctx context.Context, reqBody []byte) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -297,6 +492,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
+		req.ctx = ctx
+
 		resp, err := d.handleReq(req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
@@ -326,6 +523,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
+		req.ctx = ctx
+
 		var response, err = d.handleReq(req)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
@@ -357,12 +556,20 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
+	// the params follow the receiver and the context
+	offset := 1
+
+	if fd.hasCtx {
+		inArgs[1] = reflect.ValueOf(req.context())
+		offset = 2
+	}
+
 	inputs := make([]interface{}, fd.numParams())
 
-	for i := 0; i < fd.inNum-1; i++ {
-		val := reflect.New(fd.reqt[i+1])
+	for i := 0; i < fd.numParams(); i++ {
+		val := reflect.New(fd.reqt[i+offset])
 		inputs[i] = val.Interface()
-		inArgs[i+1] = val.Elem()
+		inArgs[i+offset] = val.Elem()
 	}
 
 	if fd.numParams() > 0 {
@@ -375,6 +582,12 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	if err := getError(output[1]); err != nil {
 		d.logInternalError(req.Method, err)
 
+		// keep the code of the limit errors, so that the clients can tell them apart
+		var limitErr *limitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
 		return nil, NewInvalidRequestError(err.Error())
 	}
 
@@ -433,9 +646,11 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) er
 		if fd.inNum, fd.reqt, err = validateFunc(funcName, fd.fv, true); err != nil {
 			return fmt.Errorf("jsonrpc: %w", err)
 		}
+		fd.hasCtx = fd.inNum > 1 && fd.reqt[1] == contextType
+
 		// check if last item is a pointer
 		if fd.numParams() != 0 {
-			last := fd.reqt[fd.numParams()]
+			last := fd.reqt[fd.inNum-1]
 			if last.Kind() == reflect.Ptr {
 				fd.isDyn = true
 			}
@@ -493,7 +708,10 @@ func validateFunc(funcName string, fv reflect.Value, _ bool) (inNum int, reqt []
 	return
 }
 
-var errt = reflect.TypeOf((*error)(nil)).Elem()
+var (
+	errt        = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
 
 func isErrorType(t reflect.Type) bool {
 	return t.Implements(errt)
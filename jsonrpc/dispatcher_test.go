@@ -1,7 +1,9 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -99,6 +101,101 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		for _, fullTx := range []bool{false, true} {
+			store := newMockStore()
+			dispatcher := newTestDispatcher(t,
+				hclog.NewNullLogger(),
+				store,
+				&dispatcherParams{
+					jsonRPCBatchLengthLimit: 20,
+					blockRangeLimit:         1000,
+				},
+			)
+			mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+			req := []byte(fmt.Sprintf(`{
+			"method": "eth_subscribe",
+			"params": ["newPendingTransactions", %t]
+		}`, fullTx))
+
+			resp, err := dispatcher.HandleWs(req, mockConnection)
+			require.NoError(t, err)
+
+			var subResp SuccessResponse
+			require.NoError(t, json.Unmarshal(resp, &subResp))
+			require.Nil(t, subResp.Error)
+
+			tx := &types.Transaction{
+				Nonce:    1,
+				GasPrice: big.NewInt(10),
+				Value:    big.NewInt(0),
+				V:        big.NewInt(1),
+				R:        big.NewInt(1),
+				S:        big.NewInt(1),
+			}
+			tx.ComputeHash()
+
+			store.emitPendingTx(tx)
+
+			var msg []byte
+			select {
+			case msg = <-msgCh:
+			case <-time.After(2 * time.Second):
+				t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+			}
+
+			var notification struct {
+				Params struct {
+					Subscription string          `json:"subscription"`
+					Result       json.RawMessage `json:"result"`
+				} `json:"params"`
+			}
+			require.NoError(t, json.Unmarshal(msg, &notification))
+
+			var subID string
+			require.NoError(t, json.Unmarshal(subResp.Result, &subID))
+			assert.Equal(t, subID, notification.Params.Subscription)
+
+			if fullTx {
+				var pendingTx transaction
+				require.NoError(t, json.Unmarshal(notification.Params.Result, &pendingTx))
+				assert.Equal(t, tx.Hash, pendingTx.Hash)
+				assert.Equal(t, argUint64(1), pendingTx.Nonce)
+			} else {
+				var hash types.Hash
+				require.NoError(t, json.Unmarshal(notification.Params.Result, &hash))
+				assert.Equal(t, tx.Hash, hash)
+			}
+		}
+	})
+
+	t.Run("\"newPendingTransactions\" should reject invalid fullTx param", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		resp, err := dispatcher.HandleWs([]byte(`{
+			"method": "eth_subscribe",
+			"params": ["newPendingTransactions", "yes"]
+		}`), mockConnection)
+		require.NoError(t, err)
+
+		var subResp SuccessResponse
+		require.NoError(t, json.Unmarshal(resp, &subResp))
+		require.NotNil(t, subResp.Error)
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -465,3 +562,127 @@ func newTestDispatcher(t *testing.T, logger hclog.Logger, store JSONRPCStore, pa
 
 	return d
 }
+
+func TestDispatcher_HandleWebsocketConnection_TraceChain(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	store.header = &types.Header{Number: 2000}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	cases := []struct {
+		name   string
+		params string
+		err    string
+	}{
+		{
+			name:   "missing end block",
+			params: `["0x1"]`,
+			err:    "Invalid params",
+		},
+		{
+			name:   "empty range",
+			params: `["0x10", "0x10", {}]`,
+			err:    ErrIncorrectBlockRange.Error(),
+		},
+		{
+			name:   "beyond the latest block",
+			params: `["0x10", "0x800", {}]`,
+			err:    ErrIncorrectBlockRange.Error(),
+		},
+		{
+			name:   "range above the limit",
+			params: `["0x0", "latest", {}]`,
+			err:    ErrBlockRangeTooHigh.Error(),
+		},
+		{
+			name:   "unknown tracer",
+			params: `["0x0", "0x10", {"tracer": "fooTracer"}]`,
+			err:    "unknown tracer",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockConnection, _ := newMockWsConnWithMsgCh()
+
+			req := []byte(`{"jsonrpc": "2.0", "id": 1, "method": "debug_traceChain", "params": ` + c.params + `}`)
+
+			data, err := dispatcher.HandleWs(req, mockConnection)
+			require.NoError(t, err)
+
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(data, &resp))
+			require.NotNil(t, resp.Error)
+			assert.Contains(t, resp.Error.Message, c.err)
+		})
+	}
+}
+
+func TestDispatcher_TxRateLimit(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	store.rateLimited["1.2.3.4"] = true
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:                 0,
+			priceLimit:              0,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	ctx := withRemoteAddr(context.Background(), "1.2.3.4")
+
+	// the transactions of a limited client are rejected
+	resp, err := dispatcher.HandleWithContext(ctx, []byte(`{
+		"method": "eth_sendRawTransaction",
+		"params": ["0x00"]
+	}`))
+	require.NoError(t, err)
+
+	var res SuccessResponse
+	require.NoError(t, json.Unmarshal(resp, &res))
+	require.NotNil(t, res.Error)
+	assert.Equal(t, -32005, res.Error.Code)
+	assert.Equal(t, errTxRateLimited.Error(), res.Error.Message)
+
+	// other clients aren't limited, the transaction fails to decode instead
+	resp, err = dispatcher.HandleWithContext(withRemoteAddr(context.Background(), "5.6.7.8"), []byte(`{
+		"method": "eth_sendRawTransaction",
+		"params": ["0x00"]
+	}`))
+	require.NoError(t, err)
+
+	res = SuccessResponse{}
+	require.NoError(t, json.Unmarshal(resp, &res))
+	require.NotNil(t, res.Error)
+	assert.Equal(t, -32600, res.Error.Code)
+
+	// other requests aren't limited
+	resp, err = dispatcher.HandleWithContext(ctx, []byte(`{
+		"method": "web3_clientVersion",
+		"params": []
+	}`))
+	require.NoError(t, err)
+
+	res = SuccessResponse{}
+	require.NoError(t, json.Unmarshal(resp, &res))
+	assert.Nil(t, res.Error)
+}
@@ -1,15 +1,20 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
 	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -215,6 +220,111 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	block.Header.BaseFee = 10
+	store.add(block)
+
+	legacyTx := newTestTransaction(0, addr0)
+	dynamicTx := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		Nonce:     1,
+		GasFeeCap: big.NewInt(100),
+		GasTipCap: big.NewInt(2),
+		Value:     big.NewInt(0),
+		From:      addr0,
+		V:         big.NewInt(1),
+		R:         big.NewInt(1),
+		S:         big.NewInt(1),
+	}
+	dynamicTx.ComputeHash()
+
+	block.Transactions = []*types.Transaction{legacyTx, dynamicTx}
+
+	contract := types.StringToAddress("5")
+	receipts := []*types.Receipt{
+		{
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			Logs:              []*types.Log{{Topics: []types.Hash{hash1}}, {Topics: []types.Hash{hash2}}},
+		},
+		{
+			CumulativeGasUsed: 71000,
+			GasUsed:           50000,
+			ContractAddress:   &contract,
+			Logs:              []*types.Log{{Topics: []types.Hash{hash3}}},
+		},
+	}
+
+	for _, rec := range receipts {
+		rec.SetStatus(types.ReceiptSuccess)
+	}
+
+	store.receipts[hash4] = receipts
+
+	res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+	require.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := res.([]*receipt)
+	require.Len(t, response, 2)
+
+	assert.Equal(t, legacyTx.Hash, response[0].TxHash)
+	assert.Equal(t, argUint64(0), response[0].TxIndex)
+	assert.Equal(t, argBig(*big.NewInt(1)), response[0].EffectiveGasPrice)
+	assert.Nil(t, response[0].ContractAddress)
+	assert.Equal(t, argUint64(0), response[0].Logs[0].LogIndex)
+	assert.Equal(t, argUint64(1), response[0].Logs[1].LogIndex)
+
+	assert.Equal(t, dynamicTx.Hash, response[1].TxHash)
+	assert.Equal(t, argUint64(1), response[1].TxIndex)
+	assert.Equal(t, argUint64(50000), response[1].GasUsed)
+	assert.Equal(t, argBig(*big.NewInt(12)), response[1].EffectiveGasPrice)
+	assert.Equal(t, &contract, response[1].ContractAddress)
+	assert.Equal(t, argUint64(2), response[1].Logs[0].LogIndex)
+	assert.Equal(t, argUint64(1), response[1].Logs[0].TxIndex)
+
+	// the single receipt has the same log indexes
+	res, err = eth.GetTransactionReceipt(dynamicTx.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, response[1], res)
+
+	// the block by number has the same receipts
+	number := BlockNumber(1)
+
+	res, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockNumber: &number})
+	require.NoError(t, err)
+	assert.Equal(t, response, res)
+
+	// unknown block
+	res, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	// invalid block params
+	res, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4, BlockNumber: &number})
+	assert.ErrorIs(t, err, ErrBlockNumberAndHash)
+	assert.Nil(t, res)
+
+	negative := BlockNumber(-5)
+
+	res, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockNumber: &negative})
+	assert.ErrorIs(t, err, ErrNegativeBlockNumber)
+	assert.Nil(t, res)
+
+	// the receipts are missing
+	delete(store.receipts, hash4)
+
+	res, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -246,24 +356,25 @@ func TestEth_Syncing(t *testing.T) {
 	})
 }
 
-// if price-limit flag is set its value should be returned if it is higher than avg gas price
+// if price-limit flag is set its value should be returned if it is higher than the suggested gas price
 func TestEth_GetPrice_PriceLimitSet(t *testing.T) {
 	priceLimit := uint64(100333)
 	store := newMockBlockStore()
+	store.add(newTestBlock(1, hash1))
 	// not using newTestEthEndpoint as we need to set priceLimit
 	eth := newTestEthEndpointWithPriceLimit(store, priceLimit)
 
-	t.Run("returns price limit flag value when it is larger than average gas price", func(t *testing.T) {
+	t.Run("returns price limit flag value when it is larger than suggested gas price", func(t *testing.T) {
 		res, err := eth.GasPrice()
-		store.averageGasPrice = 0
+		store.maxPriorityFeePerGas = 0
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 
 		assert.Equal(t, argUint64(priceLimit), res)
 	})
 
-	t.Run("returns average gas price when it is larger than set price limit flag", func(t *testing.T) {
-		store.averageGasPrice = 500000
+	t.Run("returns suggested gas price when it is larger than set price limit flag", func(t *testing.T) {
+		store.maxPriorityFeePerGas = 500000
 		res, err := eth.GasPrice()
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -274,14 +385,73 @@ func TestEth_GetPrice_PriceLimitSet(t *testing.T) {
 
 func TestEth_GasPrice(t *testing.T) {
 	store := newMockBlockStore()
-	store.averageGasPrice = 9999
+	store.maxPriorityFeePerGas = 9999
+
+	block := newTestBlock(1, hash1)
+	block.Header.BaseFee = 1000
+	store.add(block)
+
 	eth := newTestEthEndpoint(store)
 
 	res, err := eth.GasPrice()
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 
-	assert.Equal(t, argUint64(store.averageGasPrice), res)
+	// the suggested tip on top of the latest base fee
+	assert.Equal(t, argUint64(store.maxPriorityFeePerGas+1000), res)
+
+	// the price doesn't wrap around
+	block = newTestBlock(2, hash2)
+	block.Header.BaseFee = math.MaxUint64
+	store.add(block)
+
+	res, err = eth.GasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(math.MaxUint64), res)
+}
+
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	store := newMockBlockStore()
+	store.maxPriorityFeePerGas = 9999
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+
+	assert.Equal(t, argBigPtr(big.NewInt(9999)), res)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	store := newMockBlockStore()
+	store.add(newTestBlock(1, hash1), newTestBlock(2, hash2))
+	store.feeHistory = &gasprice.FeeHistoryReturn{
+		OldestBlock:   1,
+		BaseFeePerGas: []uint64{100, 200, 300},
+		GasUsedRatio:  []float64{0.5, 1},
+		Reward:        [][]*big.Int{{big.NewInt(1)}, {big.NewInt(2)}},
+	}
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.FeeHistory(2, LatestBlockNumber, []float64{50})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &feeHistoryResult{
+		OldestBlock:   1,
+		BaseFeePerGas: []argUint64{100, 200, 300},
+		GasUsedRatio:  []float64{0.5, 1},
+		Reward:        [][]argBig{{argBig(*big.NewInt(1))}, {argBig(*big.NewInt(2))}},
+	}, res)
+
+	// the newest block is resolved before querying the oracle
+	assert.Equal(t, uint64(2), store.feeHistoryNewest)
+
+	encoded, err := json.Marshal(res)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"oldestBlock":"0x1","baseFeePerGas":["0x64","0xc8","0x12c"],"gasUsedRatio":[0.5,1],"reward":[["0x1"],["0x2"]]}`,
+		string(encoded),
+	)
 }
 
 func TestEth_Call(t *testing.T) {
@@ -341,13 +511,18 @@ type testStore interface {
 
 type mockBlockStore struct {
 	testStore
-	blocks          []*types.Block
-	topics          []types.Hash
-	pendingTxns     []*types.Transaction
-	receipts        map[types.Hash][]*types.Receipt
-	isSyncing       bool
-	averageGasPrice int64
-	ethCallError    error
+	blocks               []*types.Block
+	topics               []types.Hash
+	pendingTxns          []*types.Transaction
+	receipts             map[types.Hash][]*types.Receipt
+	isSyncing            bool
+	maxPriorityFeePerGas int64
+	feeHistory           *gasprice.FeeHistoryReturn
+	feeHistoryNewest     uint64
+	ethCallError         error
+
+	// the blocks matching the logs blooms, all blocks match if nil
+	bloomCandidates map[uint64]bool
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -513,8 +688,18 @@ func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 	}
 }
 
-func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
-	return big.NewInt(m.averageGasPrice)
+func (m *mockBlockStore) MaxPriorityFeePerGas() (*big.Int, error) {
+	return big.NewInt(m.maxPriorityFeePerGas), nil
+}
+
+func (m *mockBlockStore) FeeHistory(
+	blockCount uint64,
+	newestBlock uint64,
+	rewardPercentiles []float64,
+) (*gasprice.FeeHistoryReturn, error) {
+	m.feeHistoryNewest = newestBlock
+
+	return m.feeHistory, nil
 }
 
 func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
@@ -525,10 +710,28 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxEvents(...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return nil, func() {}
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
 
+func (m *mockBlockStore) FilterBlocksByBloom(from, to uint64, _ [][][]byte) []uint64 {
+	numbers := make([]uint64, 0)
+
+	for i := from; i <= to; i++ {
+		if m.bloomCandidates != nil && !m.bloomCandidates[i] {
+			continue
+		}
+
+		numbers = append(numbers, i)
+	}
+
+	return numbers
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
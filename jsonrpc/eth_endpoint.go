@@ -1,18 +1,25 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/fastrlp"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/accesslisttracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -25,6 +32,9 @@ type ethTxPoolStore interface {
 
 	// GetNonce returns the next nonce for this address
 	GetNonce(addr types.Address) uint64
+
+	// AllowRPCTx counts a transaction sent by the given client address against its rate limit
+	AllowRPCTx(remoteAddr string) error
 }
 
 type Account struct {
@@ -37,6 +47,7 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, storageKeys []types.Hash) (*state.AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -58,12 +69,12 @@ type ethBlockchainStore interface {
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
-	// GetAvgGasPrice returns the average gas price
-	GetAvgGasPrice() *big.Int
-
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error)
 
+	// TraceCall traces a single call at the point when the given header is mined
+	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -79,6 +90,7 @@ type ethStore interface {
 	ethStateStore
 	ethBlockchainStore
 	ethFilter
+	gasprice.GasStore
 }
 
 // Eth is the eth jsonrpc endpoint
@@ -91,7 +103,9 @@ type Eth struct {
 }
 
 var (
-	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+	ErrInsufficientFunds   = errors.New("insufficient funds for execution")
+	ErrAccessListNotActive = errors.New("access lists not supported before Berlin")
+	ErrBlockNumberAndHash  = errors.New("cannot use both block number and block hash as filters")
 )
 
 // ChainId returns the chain id of the client
@@ -192,7 +206,14 @@ func (e *Eth) BlockNumber() (interface{}, error) {
 }
 
 // SendRawTransaction sends a raw transaction
-func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
+func (e *Eth) SendRawTransaction(ctx context.Context, buf argBytes) (interface{}, error) {
+	// the transactions are rate limited per client address
+	if remoteAddr := remoteAddrFromContext(ctx); remoteAddr != "" {
+		if err := e.store.AllowRPCTx(remoteAddr); err != nil {
+			return nil, NewLimitExceededError(err.Error())
+		}
+	}
+
 	tx := &types.Transaction{}
 	if err := tx.UnmarshalRLP(buf); err != nil {
 		return nil, err
@@ -331,38 +352,67 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
+	// logs are indexed within the block
+	logIndex := uint64(0)
+	for _, rec := range receipts[:indx] {
+		logIndex += uint64(len(rec.Logs))
+	}
+
+	return toReceipt(receipts[indx], block.Transactions[indx], uint64(indx), block.Header, logIndex), nil
+}
+
+// GetBlockReceipts returns all transaction receipts of the given block,
+// or null if the block is not found
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	var (
+		block *types.Block
+		ok    bool
+	)
+
+	switch {
+	case filter.BlockNumber != nil && filter.BlockHash != nil:
+		return nil, ErrBlockNumberAndHash
+
+	case filter.BlockHash != nil:
+		block, ok = e.store.GetBlockByHash(*filter.BlockHash, true)
+
+	default:
+		number := LatestBlockNumber
+		if filter.BlockNumber != nil {
+			number = *filter.BlockNumber
 		}
+
+		num, err := GetNumericBlockNumber(number, e.store)
+		if err != nil {
+			return nil, err
+		}
+
+		block, ok = e.store.GetBlockByNumber(num, true)
+	}
+
+	if !ok {
+		return nil, nil
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	if len(block.Transactions) == 0 {
+		return []*receipt{}, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the receipts of block %s: %w", block.Hash(), err)
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("receipts of block %s don't match its transactions", block.Hash())
+	}
+
+	res := make([]*receipt, len(receipts))
+	logIndex := uint64(0)
+
+	for i, raw := range receipts {
+		res[i] = toReceipt(raw, block.Transactions[i], uint64(i), block.Header, logIndex)
+		logIndex += uint64(len(raw.Logs))
 	}
 
 	return res, nil
@@ -409,14 +459,73 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(types.BytesToHash(data).Bytes()), nil
 }
 
-// GasPrice returns the average gas price based on the last x blocks
+// GetProof returns the merkle proof of the account and of its storage keys (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
+// GasPrice returns the tip suggested by the gas price oracle on top of the base fee of the latest block,
 // taking into consideration operator defined price limit
 func (e *Eth) GasPrice() (interface{}, error) {
-	// Fetch average gas price in uint64
-	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
+	tip, err := e.store.MaxPriorityFeePerGas()
+	if err != nil {
+		return nil, err
+	}
+
+	// The suggested price covers the suggested tip on top of the latest base fee
+	gasPrice := new(big.Int).Add(tip, new(big.Int).SetUint64(e.store.Header().BaseFee))
+
+	// The price is clamped to the largest one the response can hold
+	if !gasPrice.IsUint64() {
+		return argUint64(math.MaxUint64), nil
+	}
+
+	// Return --price-limit flag defined value if it is greater than gasPrice
+	return argUint64(common.Max(e.priceLimit, gasPrice.Uint64())), nil
+}
+
+// MaxPriorityFeePerGas returns the suggested tip for a dynamic fee transaction
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	tip, err := e.store.MaxPriorityFeePerGas()
+	if err != nil {
+		return nil, err
+	}
+
+	return argBigPtr(tip), nil
+}
+
+// FeeHistory returns the base fees, gas used ratios and the tip percentiles
+// of the blockCount blocks ending with newestBlock
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	newest, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := e.store.FeeHistory(uint64(blockCount), newest, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
 
-	// Return --price-limit flag defined value if it is greater than avgGasPrice
-	return argUint64(common.Max(e.priceLimit, avgGasPrice)), nil
+	return toFeeHistory(history), nil
 }
 
 type overrideAccount struct {
@@ -498,6 +607,79 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+type accessListResult struct {
+	AccessList types.TxAccessList `json:"accessList"`
+	Error      string             `json:"error,omitempty"`
+	GasUsed    argUint64          `json:"gasUsed"`
+}
+
+// CreateAccessList creates an EIP-2930 access list for the given transaction,
+// along with the gas the transaction consumes when the list is applied
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := DecodeTxn(arg, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	forksInTime := e.store.GetForksInTime(header.Number)
+	if !forksInTime.Berlin {
+		return nil, ErrAccessListNotActive
+	}
+
+	// legacy transactions don't pay for the access list, so they are traced as access list transactions
+	if transaction.Type == types.LegacyTx {
+		transaction.Type = types.AccessListTx
+	}
+
+	// the sender, recipient and precompiles are always warm, so they are left out of the list
+	excluded := precompiled.NewPrecompiled().Addresses(&forksInTime)
+	excluded = append(excluded, transaction.From)
+
+	if transaction.IsContractCreation() {
+		excluded = append(excluded, crypto.CreateAddress(transaction.From, transaction.Nonce))
+	} else {
+		excluded = append(excluded, *transaction.To)
+	}
+
+	accessList := transaction.AccessList
+
+	// applying an access list changes the gas available to the call, which may change the
+	// execution path, so the call is repeated until the resulting list doesn't change
+	for {
+		alTracer := accesslisttracer.NewAccessListTracer(accessList, excluded)
+
+		transaction.AccessList = accessList
+		if _, err := e.store.TraceCall(transaction, header, alTracer); err != nil {
+			return nil, err
+		}
+
+		if alTracer.Equal(accessList) {
+			res := &accessListResult{
+				AccessList: alTracer.AccessList(),
+				GasUsed:    argUint64(alTracer.GasUsed()),
+			}
+
+			if alTracer.Err() != nil {
+				res.Error = alTracer.Err().Error()
+			}
+
+			return res, nil
+		}
+
+		accessList = alTracer.AccessList()
+	}
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
 func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
 	transaction, err := DecodeTxn(arg, e.store)
@@ -788,6 +970,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new pending transactions arrive
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/umbracle/fastrlp"
@@ -765,12 +768,185 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+type mockVMState struct{}
+
+func (m *mockVMState) Halt() {}
+
+func TestEth_CreateAccessList(t *testing.T) {
+	store := getExampleStore()
+	store.forks = chain.ForksInTime{Berlin: true}
+	ethEndpoint := newTestEthEndpoint(store)
+
+	var (
+		callee = types.StringToAddress("0x1234")
+		slot   = types.StringToHash("4")
+		calls  = 0
+	)
+
+	// The simulated execution reads a slot of the recipient and calls another contract,
+	// spending less gas once both of them are in the access list
+	store.traceCallHook = func(
+		txn *types.Transaction,
+		header *types.Header,
+		tr tracer.Tracer,
+	) (interface{}, error) {
+		calls++
+
+		assert.Equal(t, types.AccessListTx, txn.Type)
+
+		gasLeft := txn.Gas - 50000
+		if len(txn.AccessList) == 2 {
+			gasLeft += 1000
+		}
+
+		tr.TxStart(txn.Gas)
+		tr.CaptureState(
+			nil,
+			[]*big.Int{new(big.Int).SetBytes(slot.Bytes())},
+			evm.SLOAD, addr1, 1, nil, &mockVMState{},
+		)
+		tr.CaptureState(
+			nil,
+			[]*big.Int{new(big.Int).SetBytes(callee.Bytes()), big.NewInt(1000)},
+			evm.CALL, addr1, 2, nil, &mockVMState{},
+		)
+		tr.CaptureState(
+			nil,
+			[]*big.Int{new(big.Int).SetBytes(addr0.Bytes()), big.NewInt(1000)},
+			evm.CALL, addr1, 2, nil, &mockVMState{},
+		)
+		tr.CallEnd(1, nil, nil)
+		tr.TxEnd(gasLeft)
+
+		return tr.GetResult()
+	}
+
+	res, err := ethEndpoint.CreateAccessList(constructMockTx(argUintPtr(100000), nil), BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	// the first run discovers the list, the second one confirms it
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, &accessListResult{
+		AccessList: types.TxAccessList{
+			{Address: addr1, StorageKeys: []types.Hash{slot}},
+			{Address: callee, StorageKeys: []types.Hash{}},
+		},
+		GasUsed: argUint64(49000),
+	}, res)
+}
+
+func TestEth_CreateAccessList_Errors(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	store.traceCallHook = func(
+		txn *types.Transaction,
+		header *types.Header,
+		tr tracer.Tracer,
+	) (interface{}, error) {
+		return nil, state.ErrNotEnoughIntrinsicGas
+	}
+
+	// the access lists don't exist before Berlin
+	res, err := ethEndpoint.CreateAccessList(constructMockTx(nil, nil), BlockNumberOrHash{})
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, ErrAccessListNotActive)
+
+	store.forks = chain.ForksInTime{Berlin: true}
+
+	res, err = ethEndpoint.CreateAccessList(constructMockTx(nil, nil), BlockNumberOrHash{})
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, state.ErrNotEnoughIntrinsicGas)
+}
+
+func TestEth_GetProof(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	var (
+		slot        = types.StringToHash("4")
+		storageRoot = types.StringToHash("5")
+		codeHash    = types.StringToHash("6")
+	)
+
+	store.getProofHook = func(
+		root types.Hash,
+		addr types.Address,
+		storageKeys []types.Hash,
+	) (*state.AccountProof, error) {
+		assert.Equal(t, store.block.Header.StateRoot, root)
+
+		res := &state.AccountProof{
+			Proof:        [][]byte{{0x1, 0x2}, {0x3}},
+			StorageProof: make([]*state.StorageProof, len(storageKeys)),
+		}
+
+		if addr == addr0 {
+			res.Account = &state.Account{
+				Nonce:    2,
+				Balance:  big.NewInt(100),
+				Root:     storageRoot,
+				CodeHash: codeHash.Bytes(),
+			}
+		}
+
+		for i, key := range storageKeys {
+			res.StorageProof[i] = &state.StorageProof{
+				Key:   key,
+				Value: types.StringToHash("0x10"),
+				Proof: [][]byte{{0x4}},
+			}
+		}
+
+		return res, nil
+	}
+
+	latest := LatestBlockNumber
+
+	res, err := ethEndpoint.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockNumber: &latest})
+	assert.NoError(t, err)
+
+	encoded, err := json.Marshal(res)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"address": "`+addr0.String()+`",
+		"accountProof": ["0x0102", "0x03"],
+		"balance": "0x64",
+		"codeHash": "`+codeHash.String()+`",
+		"nonce": "0x2",
+		"storageHash": "`+storageRoot.String()+`",
+		"storageProof": [{"key": "`+slot.String()+`", "value": "0x10", "proof": ["0x04"]}]
+	}`, string(encoded))
+
+	// missing accounts are reported as empty ones
+	res, err = ethEndpoint.GetProof(addr1, nil, BlockNumberOrHash{BlockNumber: &latest})
+	assert.NoError(t, err)
+
+	proof, ok := res.(*accountProofResult)
+	assert.True(t, ok)
+	assert.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+	assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+	assert.Equal(t, argUint64(0), proof.Nonce)
+	assert.Empty(t, proof.StorageProof)
+
+	// unknown block
+	missingBlock := BlockNumber(10)
+
+	_, err = ethEndpoint.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &missingBlock})
+	assert.Error(t, err)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
+	forks   chain.ForksInTime
 
-	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	applyTxnHook  func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	traceCallHook func(txn *types.Transaction, header *types.Header, tracer tracer.Tracer) (interface{}, error)
+	getProofHook  func(root types.Hash, addr types.Address, storageKeys []types.Hash) (*state.AccountProof, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -836,8 +1012,20 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(
+	root types.Hash,
+	addr types.Address,
+	storageKeys []types.Hash,
+) (*state.AccountProof, error) {
+	if m.getProofHook != nil {
+		return m.getProofHook(root, addr, storageKeys)
+	}
+
+	return nil, ErrStateNotFound
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
-	return chain.ForksInTime{}
+	return m.forks
 }
 
 func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
@@ -847,3 +1035,11 @@ func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+func (m *mockSpecialStore) TraceCall(txn *types.Transaction, header *types.Header, tracer tracer.Tracer) (interface{}, error) {
+	if m.traceCallHook != nil {
+		return m.traceCallHook(txn, header, tracer)
+	}
+
+	return tracer.GetResult()
+}
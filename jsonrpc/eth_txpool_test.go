@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	txn.ComputeHash()
 
 	data := txn.MarshalRLP()
-	_, err := eth.SendRawTransaction(data)
+	_, err := eth.SendRawTransaction(context.Background(), data)
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 
@@ -41,7 +42,7 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 		GasPrice: big.NewInt(int64(1)),
 	}
 
-	_, err := eth.SendRawTransaction(txToSend.MarshalRLP())
+	_, err := eth.SendRawTransaction(context.Background(), txToSend.MarshalRLP())
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
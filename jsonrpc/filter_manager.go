@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -212,6 +213,70 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions promoted in the txpool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+
+	// fullTx indicates the whole transactions are sent to web socket stream instead of hashes
+	fullTx bool
+	txs    []*types.Transaction
+}
+
+// appendTx appends new transaction to txs
+func (f *pendingTxFilter) appendTx(tx *types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.txs = append(f.txs, tx)
+}
+
+// takeTxUpdates returns all saved transactions in filter and set new transaction slice
+func (f *pendingTxFilter) takeTxUpdates() []*types.Transaction {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []*types.Transaction{}
+
+	return txs
+}
+
+// getUpdates returns the hashes of stored transactions in string
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	txs := f.takeTxUpdates()
+
+	updates := make([]string, len(txs))
+	for index, tx := range txs {
+		updates[index] = tx.Hash.String()
+	}
+
+	return updates, nil
+}
+
+// sendUpdates writes stored transactions (or their hashes) to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	txs := f.takeTxUpdates()
+
+	for _, tx := range txs {
+		var update interface{} = tx.Hash
+		if f.fullTx {
+			update = toPendingTransaction(tx)
+		}
+
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +293,16 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// SubscribeTxEvents subscribes for the given types of txpool events
+	SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func())
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// FilterBlocksByBloom returns the numbers of the blocks in the range
+	// which possibly contain logs matching the filter
+	FilterBlocksByBloom(from, to uint64, filter [][][]byte) []uint64
 }
 
 // FilterManager manages all running filters
@@ -243,6 +318,10 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	// txpool subscription for the promoted transactions
+	txSubscription       <-chan *proto.TxPoolEvent
+	cancelTxSubscription func()
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
@@ -273,6 +352,9 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// start the pending transactions watcher
+	m.txSubscription, m.cancelTxSubscription = store.SubscribeTxEvents(proto.EventType_PROMOTED)
+
 	return m
 }
 
@@ -291,6 +373,19 @@ func (f *FilterManager) Run() {
 		}
 	}()
 
+	// watch for new promoted transactions in the txpool
+	pendingTxCh := make(chan types.Hash)
+
+	go func() {
+		for evnt := range f.txSubscription {
+			select {
+			case pendingTxCh <- types.StringToHash(evnt.TxHash):
+			case <-f.closeCh:
+				return
+			}
+		}
+	}()
+
 	var timeoutCh <-chan time.Time
 
 	for {
@@ -309,6 +404,12 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case txHash := <-pendingTxCh:
+			// new promoted transaction
+			if err := f.dispatchPendingTx(txHash); err != nil {
+				f.logger.Error("failed to dispatch pending transaction", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -329,6 +430,7 @@ func (f *FilterManager) Run() {
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
 	close(f.closeCh)
+	f.cancelTxSubscription()
 }
 
 // NewBlockFilter adds new BlockFilter
@@ -359,6 +461,20 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -428,7 +544,8 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
+	// narrow the blocks down using the logs blooms before reading the receipts
+	for _, i := range f.store.FilterBlocksByBloom(from, to, query.bloomFilter()) {
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
@@ -558,12 +675,17 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	return true
 }
 
-// RemoveFilterByWs removes the filter with given WS [Thread safe]
+// RemoveFilterByWs removes all filters with given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
 	defer f.Unlock()
 
-	f.removeFilterByID(ws.GetFilterID())
+	// the connection may hold more than one subscription
+	for id, filter := range f.filters {
+		if filter.getFilterBase().ws == ws {
+			f.removeFilterByID(id)
+		}
+	}
 }
 
 // refreshFilterTimeout updates the timeout for a filter to the current time
@@ -651,6 +773,43 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	}
 }
 
+// dispatchPendingTx is an event handler for new promoted transaction
+func (f *FilterManager) dispatchPendingTx(txHash types.Hash) error {
+	// store new transaction in each filters
+	f.processPendingTx(txHash)
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
+// processPendingTx makes each PendingTxFilter append the promoted transaction
+func (f *FilterManager) processPendingTx(txHash types.Hash) {
+	f.RLock()
+	defer f.RUnlock()
+
+	pendingTxFilters := make([]*pendingTxFilter, 0)
+
+	for _, f := range f.filters {
+		if pendingTxFilter, ok := f.(*pendingTxFilter); ok {
+			pendingTxFilters = append(pendingTxFilters, pendingTxFilter)
+		}
+	}
+
+	if len(pendingTxFilters) == 0 {
+		return
+	}
+
+	tx, ok := f.store.GetPendingTx(txHash)
+	if !ok {
+		// the transaction has already left the pool
+		return
+	}
+
+	for _, filter := range pendingTxFilters {
+		filter.appendTx(tx)
+	}
+}
+
 // appendLogsToFilters makes each LogFilters append logs in the header
 func (f *FilterManager) appendLogsToFilters(header *block) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
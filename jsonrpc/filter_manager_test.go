@@ -155,6 +155,54 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_BloomCandidates(t *testing.T) {
+	t.Parallel()
+
+	// every block has a matching log, but only the logs bloom of block 2 matches
+	store := newMockBlockStore()
+	store.bloomCandidates = map[uint64]bool{2: true}
+
+	for i := 0; i < 4; i++ {
+		block := &types.Block{
+			Header: &types.Header{
+				Number: uint64(i),
+				Hash:   types.StringToHash(strconv.Itoa(i)),
+			},
+			Transactions: []*types.Transaction{
+				createTestTransaction(types.StringToHash(fmt.Sprintf("tx%d", i))),
+			},
+		}
+
+		store.add(block)
+		store.receipts[block.Hash()] = []*types.Receipt{
+			{
+				Logs: []*types.Log{
+					{
+						Topics: []types.Hash{hash1},
+					},
+				},
+			},
+		}
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	logs, err := f.GetLogsForQuery(&LogQuery{
+		fromBlock: 1,
+		toBlock:   3,
+		Topics:    [][]types.Hash{{hash1}},
+	})
+	require.NoError(t, err)
+
+	// only the receipts of the candidate blocks are read
+	require.Len(t, logs, 1)
+	assert.Equal(t, argUint64(2), logs[0].BlockNumber)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
@@ -383,6 +431,43 @@ func TestFilterBlock(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	// add pending tx filter
+	id := m.NewPendingTxFilter(false, nil)
+
+	txs := make([]*types.Transaction, 2)
+	for i := range txs {
+		txs[i] = &types.Transaction{Nonce: uint64(i)}
+		txs[i].ComputeHash()
+
+		store.emitPendingTx(txs[i])
+	}
+
+	// we need to wait for the manager to process the data
+	time.Sleep(500 * time.Millisecond)
+
+	changes, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{txs[0].Hash.String(), txs[1].Hash.String()}, changes)
+
+	// the changes are drained by the previous poll
+	changes, err = m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, changes)
+
+	assert.True(t, m.Uninstall(id))
+	assert.False(t, m.Exists(id))
+}
+
 func TestFilterTimeout(t *testing.T) {
 	t.Parallel()
 
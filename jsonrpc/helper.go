@@ -221,16 +221,22 @@ func DecodeTxn(arg *txnArgs, store nonceGetter) (*types.Transaction, error) {
 		txType = types.TxType(*arg.Type)
 	}
 
+	var accessList types.TxAccessList
+	if arg.AccessList != nil {
+		accessList = *arg.AccessList
+	}
+
 	txn := &types.Transaction{
-		From:      *arg.From,
-		Gas:       uint64(*arg.Gas),
-		GasPrice:  new(big.Int).SetBytes(*arg.GasPrice),
-		GasTipCap: new(big.Int).SetBytes(*arg.GasTipCap),
-		GasFeeCap: new(big.Int).SetBytes(*arg.GasFeeCap),
-		Value:     new(big.Int).SetBytes(*arg.Value),
-		Input:     input,
-		Nonce:     uint64(*arg.Nonce),
-		Type:      txType,
+		From:       *arg.From,
+		Gas:        uint64(*arg.Gas),
+		GasPrice:   new(big.Int).SetBytes(*arg.GasPrice),
+		GasTipCap:  new(big.Int).SetBytes(*arg.GasTipCap),
+		GasFeeCap:  new(big.Int).SetBytes(*arg.GasFeeCap),
+		Value:      new(big.Int).SetBytes(*arg.Value),
+		Input:      input,
+		Nonce:      uint64(*arg.Nonce),
+		Type:       txType,
+		AccessList: accessList,
 	}
 
 	if arg.To != nil {
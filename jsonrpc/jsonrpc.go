@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,8 +45,8 @@ type JSONRPC struct {
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWsWithContext(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error)
+	HandleWithContext(ctx context.Context, reqBody []byte) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -230,6 +231,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}(ws)
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	ctx := withRemoteAddr(req.Context(), remoteIP(req))
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -256,7 +258,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWsWithContext(ctx, message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -264,7 +266,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 						msgType,
 						[]byte(fmt.Sprintf("WS Handle error: %s", handleErr.Error())),
 					)
-				} else {
+				} else if resp != nil {
 					_ = wrapConn.WriteMessage(msgType, resp)
 				}
 			}()
@@ -303,7 +305,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.HandleWithContext(withRemoteAddr(req.Context(), remoteIP(req)), data)
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -314,6 +316,30 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Debug("handle", "response", string(resp))
 }
 
+// remoteIP returns the IP address of the client sending the request
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+type remoteAddrKey struct{}
+
+// withRemoteAddr returns the context carrying the address of the client sending the request
+func withRemoteAddr(ctx context.Context, remoteAddr string) context.Context {
+	return context.WithValue(ctx, remoteAddrKey{}, remoteAddr)
+}
+
+// remoteAddrFromContext returns the address of the client sending the request, empty if it isn't known
+func remoteAddrFromContext(ctx context.Context) string {
+	remoteAddr, _ := ctx.Value(remoteAddrKey{}).(string)
+
+	return remoteAddr
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
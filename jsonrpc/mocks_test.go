@@ -1,13 +1,17 @@
 package jsonrpc
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var errTxRateLimited = errors.New("transaction rate limit exceeded")
+
 type mockAccount struct {
 	address types.Address
 	code    []byte
@@ -50,6 +54,13 @@ type mockStore struct {
 	receipts     map[types.Hash][]*types.Receipt
 	accounts     map[types.Address]*Account
 
+	txEvents    chan *proto.TxPoolEvent
+	pendingLock sync.Mutex
+	pendingTxs  map[types.Hash]*types.Transaction
+
+	// client addresses which exceeded their transaction rate limit
+	rateLimited map[string]bool
+
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
 }
@@ -59,6 +70,9 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+		txEvents:     make(chan *proto.TxPoolEvent),
+		pendingTxs:   map[types.Hash]*types.Transaction{},
+		rateLimited:  map[string]bool{},
 	}
 	m.addHeader(m.header)
 
@@ -108,6 +122,18 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	m.subscription.Push(bEvnt)
 }
 
+// emitPendingTx adds the transaction to the pool and emits its promotion
+func (m *mockStore) emitPendingTx(tx *types.Transaction) {
+	m.pendingLock.Lock()
+	m.pendingTxs[tx.Hash] = tx
+	m.pendingLock.Unlock()
+
+	m.txEvents <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+}
+
 func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	if acc, ok := m.accounts[addr]; ok {
 		return acc, nil
@@ -137,6 +163,27 @@ func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
 
+func (m *mockStore) SubscribeTxEvents(...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return m.txEvents, func() {}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingLock.Lock()
+	defer m.pendingLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) AllowRPCTx(remoteAddr string) error {
+	if m.rateLimited[remoteAddr] {
+		return errTxRateLimited
+	}
+
+	return nil
+}
+
 func (m *mockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	header := m.headerLoop(func(header *types.Header) bool {
 		return header.Number == num
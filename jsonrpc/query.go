@@ -174,6 +174,30 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// bloomFilter returns the values the logs bloom of a block has to contain to match the query,
+// grouped by the address and topic positions. A block has to contain a value of every group
+func (q *LogQuery) bloomFilter() [][][]byte {
+	filter := make([][][]byte, 0, len(q.Topics)+1)
+
+	addresses := make([][]byte, len(q.Addresses))
+	for i, addr := range q.Addresses {
+		addresses[i] = addr.Bytes()
+	}
+
+	filter = append(filter, addresses)
+
+	for _, sub := range q.Topics {
+		topics := make([][]byte, len(sub))
+		for i, topic := range sub {
+			topics[i] = topic.Bytes()
+		}
+
+		filter = append(filter, topics)
+	}
+
+	return filter
+}
+
 // Match returns whether the receipt includes topics for this filter
 func (q *LogQuery) Match(log *types.Log) bool {
 	// check addresses
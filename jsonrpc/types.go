@@ -5,7 +5,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -15,23 +17,24 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    argBig         `json:"gasPrice"`
-	GasTipCap   *argBig        `json:"gasTipCap,omitempty"`
-	GasFeeCap   *argBig        `json:"gasFeeCap,omitempty"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
-	Type        argUint64      `json:"type"`
+	Nonce       argUint64          `json:"nonce"`
+	GasPrice    argBig             `json:"gasPrice"`
+	GasTipCap   *argBig            `json:"gasTipCap,omitempty"`
+	GasFeeCap   *argBig            `json:"gasFeeCap,omitempty"`
+	Gas         argUint64          `json:"gas"`
+	To          *types.Address     `json:"to"`
+	Value       argBig             `json:"value"`
+	Input       argBytes           `json:"input"`
+	V           argBig             `json:"v"`
+	R           argBig             `json:"r"`
+	S           argBig             `json:"s"`
+	Hash        types.Hash         `json:"hash"`
+	From        types.Address      `json:"from"`
+	BlockHash   *types.Hash        `json:"blockHash"`
+	BlockNumber *argUint64         `json:"blockNumber"`
+	TxIndex     *argUint64         `json:"transactionIndex"`
+	Type        argUint64          `json:"type"`
+	AccessList  types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -70,6 +73,10 @@ func toTransaction(
 		Type:     argUint64(t.Type),
 	}
 
+	if t.Type == types.AccessListTx || t.Type == types.DynamicFeeTx {
+		res.AccessList = t.AccessList
+	}
+
 	if t.GasTipCap != nil {
 		gasTipCap := argBig(*t.GasTipCap)
 		res.GasTipCap = &gasTipCap
@@ -198,6 +205,54 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	EffectiveGasPrice argBig         `json:"effectiveGasPrice"`
+}
+
+// toReceipt converts the receipt of the transaction at the given index of the block,
+// logIndex is the index of the first log of the receipt within the block
+func toReceipt(
+	src *types.Receipt,
+	txn *types.Transaction,
+	txIndex uint64,
+	header *types.Header,
+	logIndex uint64,
+) *receipt {
+	logs := make([]*Log, len(src.Logs))
+	for i, elem := range src.Logs {
+		logs[i] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex + uint64(i)),
+			Removed:     false,
+		}
+	}
+
+	res := &receipt{
+		Root:              src.Root,
+		CumulativeGasUsed: argUint64(src.CumulativeGasUsed),
+		LogsBloom:         src.LogsBloom,
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         header.Hash,
+		BlockNumber:       argUint64(header.Number),
+		GasUsed:           argUint64(src.GasUsed),
+		ContractAddress:   src.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+		EffectiveGasPrice: argBig(*txn.GetGasPrice(header.BaseFee)),
+	}
+
+	if src.Status != nil {
+		res.Status = argUint64(*src.Status)
+	}
+
+	return res
 }
 
 type Log struct {
@@ -212,6 +267,92 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+type feeHistoryResult struct {
+	OldestBlock   argUint64   `json:"oldestBlock"`
+	BaseFeePerGas []argUint64 `json:"baseFeePerGas"`
+	GasUsedRatio  []float64   `json:"gasUsedRatio"`
+	Reward        [][]argBig  `json:"reward,omitempty"`
+}
+
+func toFeeHistory(h *gasprice.FeeHistoryReturn) *feeHistoryResult {
+	res := &feeHistoryResult{
+		OldestBlock:   argUint64(h.OldestBlock),
+		BaseFeePerGas: make([]argUint64, len(h.BaseFeePerGas)),
+		GasUsedRatio:  h.GasUsedRatio,
+	}
+
+	for i, baseFee := range h.BaseFeePerGas {
+		res.BaseFeePerGas[i] = argUint64(baseFee)
+	}
+
+	if h.Reward != nil {
+		res.Reward = make([][]argBig, len(h.Reward))
+
+		for i, rewards := range h.Reward {
+			res.Reward[i] = make([]argBig, len(rewards))
+
+			for j, reward := range rewards {
+				res.Reward[i][j] = argBig(*reward)
+			}
+		}
+	}
+
+	return res
+}
+
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+func toProofNodes(proof [][]byte) []argBytes {
+	res := make([]argBytes, len(proof))
+	for i, node := range proof {
+		res[i] = argBytes(node)
+	}
+
+	return res
+}
+
+func toAccountProof(addr types.Address, p *state.AccountProof) *accountProofResult {
+	res := &accountProofResult{
+		Address:      addr,
+		AccountProof: toProofNodes(p.Proof),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]*storageProofResult, len(p.StorageProof)),
+	}
+
+	// a missing account is reported as an empty one
+	if p.Account != nil {
+		res.Balance = argBig(*p.Account.Balance)
+		res.CodeHash = types.BytesToHash(p.Account.CodeHash)
+		res.Nonce = argUint64(p.Account.Nonce)
+		res.StorageHash = p.Account.Root
+	}
+
+	for i, sp := range p.StorageProof {
+		res.StorageProof[i] = &storageProofResult{
+			Key:   sp.Key,
+			Value: argBig(*new(big.Int).SetBytes(sp.Value.Bytes())),
+			Proof: toProofNodes(sp.Proof),
+		}
+	}
+
+	return res
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
@@ -323,17 +464,18 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From      *types.Address
-	To        *types.Address
-	Gas       *argUint64
-	GasPrice  *argBytes
-	GasTipCap *argBytes
-	GasFeeCap *argBytes
-	Value     *argBytes
-	Data      *argBytes
-	Input     *argBytes
-	Nonce     *argUint64
-	Type      *argUint64
+	From       *types.Address
+	To         *types.Address
+	Gas        *argUint64
+	GasPrice   *argBytes
+	GasTipCap  *argBytes
+	GasFeeCap  *argBytes
+	Value      *argBytes
+	Data       *argBytes
+	Input      *argBytes
+	Nonce      *argUint64
+	Type       *argUint64
+	AccessList *types.TxAccessList
 }
 
 type progression struct {
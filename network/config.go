@@ -20,6 +20,12 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+
+	StaticPeers       []string // the addresses of the peers the node always stays connected to
+	TrustedPeers      []string // the IDs of the peers which are not subject to the connection limits
+	RestrictDiscovery bool     // flag indicating if only the static and trusted peers can be connected to
+	PrivatePeers      []string // the IDs of the trusted peers which are never shared through the discovery
+	PrivateMode       bool     // flag indicating if the node is hidden behind its static peers (sentry nodes)
 }
 
 func DefaultConfig() *Config {
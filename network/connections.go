@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 // ConnectionInfo keeps track of current connection information
@@ -26,13 +27,22 @@ type ConnectionInfo struct {
 	// CONNECTION LIMITS //
 	maxInboundConnectionCount  int64
 	maxOutboundConnectionCount int64
+
+	// peers which are not subject to the connection limits
+	trustedPeers map[peer.ID]struct{}
 }
 
 // NewBlankConnectionInfo returns a cleared ConnectionInfo instance
 func NewBlankConnectionInfo(
 	maxInboundConnCount int64,
 	maxOutboundConnCount int64,
+	trustedPeers []peer.ID,
 ) *ConnectionInfo {
+	trusted := make(map[peer.ID]struct{}, len(trustedPeers))
+	for _, id := range trustedPeers {
+		trusted[id] = struct{}{}
+	}
+
 	return &ConnectionInfo{
 		inboundConnectionCount:         0,
 		outboundConnectionCount:        0,
@@ -40,6 +50,7 @@ func NewBlankConnectionInfo(
 		pendingOutboundConnectionCount: 0,
 		maxInboundConnectionCount:      maxInboundConnCount,
 		maxOutboundConnectionCount:     maxOutboundConnCount,
+		trustedPeers:                   trusted,
 	}
 }
 
@@ -137,6 +148,20 @@ func (ci *ConnectionInfo) UpdatePendingConnCountByDirection(
 	}
 }
 
+// IsTrusted checks if the peer is not subject to the connection limits.
+// [Thread safe] since the trusted peers are unchanged during runtime
+func (ci *ConnectionInfo) IsTrusted(peerID peer.ID) bool {
+	_, ok := ci.trustedPeers[peerID]
+
+	return ok
+}
+
+// HasFreeConnectionSlotForPeer checks if there is a free connection slot
+// for the peer in the specified direction. Trusted peers always have one [Thread safe]
+func (ci *ConnectionInfo) HasFreeConnectionSlotForPeer(peerID peer.ID, direction network.Direction) bool {
+	return ci.IsTrusted(peerID) || ci.HasFreeConnectionSlot(direction)
+}
+
 // HasFreeConnectionSlot checks if there is a free connection slot in the
 // specified direction [Thread safe]
 func (ci *ConnectionInfo) HasFreeConnectionSlot(direction network.Direction) bool {
@@ -0,0 +1,27 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionInfo_TrustedPeers(t *testing.T) {
+	t.Parallel()
+
+	trustedPeer := peer.ID("trusted")
+	connInfo := NewBlankConnectionInfo(1, 1, []peer.ID{trustedPeer})
+
+	connInfo.UpdateConnCountByDirection(1, network.DirInbound)
+	connInfo.UpdateConnCountByDirection(1, network.DirOutbound)
+
+	for _, direction := range []network.Direction{network.DirInbound, network.DirOutbound} {
+		assert.False(t, connInfo.HasFreeConnectionSlot(direction))
+		assert.False(t, connInfo.HasFreeConnectionSlotForPeer(peer.ID("other"), direction))
+
+		// trusted peers bypass the limits
+		assert.True(t, connInfo.HasFreeConnectionSlotForPeer(trustedPeer, direction))
+	}
+}
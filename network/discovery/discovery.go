@@ -67,6 +67,9 @@ type networkingServer interface {
 	// GetRandomPeer fetches a random peer from the server's peer store
 	GetRandomPeer() *peer.ID
 
+	// IsPrivatePeer checks if the peer must not be shared with other peers
+	IsPrivatePeer(peerID peer.ID) bool
+
 	// TEMPORARY DIALING //
 
 	// FetchOrSetTemporaryDial checks if the peer connection is a temporary dial,
@@ -423,6 +426,11 @@ func (d *DiscoveryService) FindPeers(
 			continue
 		}
 
+		if d.baseServer.IsPrivatePeer(id) {
+			// Skip the peers which must stay hidden from the network
+			continue
+		}
+
 		if info := d.baseServer.GetPeerInfo(id); len(info.Addrs) > 0 {
 			addr, err := common.AddrInfoToString(info)
 			if err != nil {
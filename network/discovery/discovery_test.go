@@ -8,6 +8,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/network/common"
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -301,3 +302,49 @@ func TestDiscoveryService_RegularPeerDiscoveryUnconnected(t *testing.T) {
 	// Make sure that no peers were added to the peer store
 	assert.Len(t, peerStore, 0)
 }
+
+// TestDiscoveryService_FindPeersPrivate makes sure the private peers
+// are never shared through the discovery
+func TestDiscoveryService_FindPeersPrivate(t *testing.T) {
+	randomPeers := getRandomPeers(t, 3)
+	peerStore := make(map[peer.ID]*peer.AddrInfo)
+	privatePeer := randomPeers[1]
+
+	discoveryService, setupErr := newDiscoveryService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookAddToPeerStore(func(info *peer.AddrInfo) {
+				peerStore[info.ID] = info
+			})
+
+			server.HookGetPeerInfo(func(id peer.ID) *peer.AddrInfo {
+				return peerStore[id]
+			})
+
+			server.HookIsPrivatePeer(func(id peer.ID) bool {
+				return id == privatePeer.ID
+			})
+		},
+	)
+	if setupErr != nil {
+		t.Fatalf("Unable to setup the discovery service")
+	}
+
+	for _, randomPeer := range randomPeers {
+		assert.NoError(t, discoveryService.addToTable(randomPeer))
+	}
+
+	resp, err := discoveryService.FindPeers(
+		&networkGrpc.Context{
+			Context: context.Background(),
+			PeerID:  randomPeers[0].ID,
+		},
+		&proto.FindPeersReq{Count: 16},
+	)
+	assert.NoError(t, err)
+
+	// Neither the requesting peer nor the private peer are shared
+	expectedNode, err := common.AddrInfoToString(randomPeers[2])
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{expectedNode}, resp.Nodes)
+}
@@ -50,8 +50,8 @@ type networkingServer interface {
 
 	// CONNECTION INFORMATION //
 
-	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
-	HasFreeConnectionSlot(direction network.Direction) bool
+	// HasFreeConnectionSlotForPeer checks if there is an available connection slot for the peer [Thread safe]
+	HasFreeConnectionSlotForPeer(peerID peer.ID, direction network.Direction) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -93,7 +93,7 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.HasFreeConnectionSlotForPeer(peerID, conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
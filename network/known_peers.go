@@ -0,0 +1,218 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// knownPeersFile is the name of the file in the data directory the known peers are saved to
+	knownPeersFile = "known_peers.json"
+
+	// knownPeersSaveInterval is the interval the known peers are saved at
+	knownPeersSaveInterval = 5 * time.Minute
+
+	// knownPeerTTL is the time after which a peer that hasn't been seen is forgotten
+	knownPeerTTL = 7 * 24 * time.Hour
+
+	// maxKnownPeers is the maximum number of the saved peers
+	maxKnownPeers = 256
+)
+
+// knownPeer is the saved information about a peer
+type knownPeer struct {
+	ID        peer.ID   `json:"id"`
+	Addrs     []string  `json:"addrs"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Successes uint64    `json:"successes"`
+	Failures  uint64    `json:"failures"`
+}
+
+// addrInfo returns the dialable information of the peer, skipping the malformed addresses
+func (p *knownPeer) addrInfo() *peer.AddrInfo {
+	info := &peer.AddrInfo{
+		ID:    p.ID,
+		Addrs: make([]multiaddr.Multiaddr, 0, len(p.Addrs)),
+	}
+
+	for _, rawAddr := range p.Addrs {
+		if addr, err := multiaddr.NewMultiaddr(rawAddr); err == nil {
+			info.Addrs = append(info.Addrs, addr)
+		}
+	}
+
+	return info
+}
+
+// knownPeers keeps track of the peers the node has connected to,
+// so they can be dialed after a restart without relying on bootnodes.
+// The peers are saved to a file in the data directory
+type knownPeers struct {
+	path string
+
+	lock  sync.Mutex
+	peers map[peer.ID]*knownPeer
+
+	now func() time.Time
+}
+
+func newKnownPeers(path string) *knownPeers {
+	return &knownPeers{
+		path:  path,
+		peers: make(map[peer.ID]*knownPeer),
+		now:   time.Now,
+	}
+}
+
+// load reads the saved peers, it is a noop if nothing has been saved yet
+func (k *knownPeers) load() error {
+	data, err := os.ReadFile(k.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var peers []*knownPeer
+	if err := json.Unmarshal(data, &peers); err != nil {
+		return err
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	for _, p := range peers {
+		k.peers[p.ID] = p
+	}
+
+	return nil
+}
+
+// save writes the peers to the file, forgetting the peers which haven't been seen for long
+func (k *knownPeers) save() error {
+	peers := k.prune()
+
+	data, err := json.Marshal(peers)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0750); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a crash can't leave a truncated file behind
+	tmpPath := k.path + ".new"
+
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, k.path)
+}
+
+// prune forgets the stale peers and the worst peers above the limit.
+// Returns copies of the remaining peers, best first
+func (k *knownPeers) prune() []*knownPeer {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	now := k.now()
+	peers := make([]*knownPeer, 0, len(k.peers))
+
+	for id, p := range k.peers {
+		if now.Sub(p.LastSeen) > knownPeerTTL {
+			delete(k.peers, id)
+
+			continue
+		}
+
+		// connected replaces the addresses, so the copy can be used without the lock
+		peerCopy := *p
+		peers = append(peers, &peerCopy)
+	}
+
+	sortKnownPeers(peers)
+
+	if len(peers) > maxKnownPeers {
+		for _, p := range peers[maxKnownPeers:] {
+			delete(k.peers, p.ID)
+		}
+
+		peers = peers[:maxKnownPeers]
+	}
+
+	return peers
+}
+
+// connected records a successful connection to the peer
+func (k *knownPeers) connected(info peer.AddrInfo) {
+	if len(info.Addrs) == 0 {
+		return
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	p, ok := k.peers[info.ID]
+	if !ok {
+		p = &knownPeer{ID: info.ID}
+		k.peers[info.ID] = p
+	}
+
+	p.Addrs = make([]string, len(info.Addrs))
+	for i, addr := range info.Addrs {
+		p.Addrs[i] = addr.String()
+	}
+
+	p.LastSeen = k.now()
+	p.Successes++
+}
+
+// failed records a failed dial to the peer, unknown peers are ignored
+func (k *knownPeers) failed(id peer.ID) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if p, ok := k.peers[id]; ok {
+		p.Failures++
+	}
+}
+
+// addrInfos returns the dialable information of the known peers, best first
+func (k *knownPeers) addrInfos() []*peer.AddrInfo {
+	peers := k.prune()
+	infos := make([]*peer.AddrInfo, 0, len(peers))
+
+	for _, p := range peers {
+		if info := p.addrInfo(); len(info.Addrs) != 0 {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// sortKnownPeers sorts the peers by the ratio of the successful connections,
+// the recently seen peers come first for the same ratio
+func sortKnownPeers(peers []*knownPeer) {
+	ratio := func(p *knownPeer) float64 {
+		return float64(p.Successes) / float64(p.Successes+p.Failures+1)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		if ri, rj := ratio(peers[i]), ratio(peers[j]); ri != rj {
+			return ri > rj
+		}
+
+		return peers[i].LastSeen.After(peers[j].LastSeen)
+	})
+}
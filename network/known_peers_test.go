@@ -0,0 +1,82 @@
+package network
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAddrInfo(t *testing.T) peer.AddrInfo {
+	t.Helper()
+
+	info, err := peer.AddrInfoFromP2pAddr(tests.GenerateTestMultiAddr(t))
+	require.NoError(t, err)
+
+	return *info
+}
+
+func TestKnownPeers_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "libp2p", knownPeersFile)
+
+	known := newKnownPeers(path)
+
+	// nothing has been saved yet
+	require.NoError(t, known.load())
+	assert.Empty(t, known.addrInfos())
+
+	peer1, peer2 := newTestAddrInfo(t), newTestAddrInfo(t)
+
+	known.connected(peer1)
+	known.connected(peer2)
+	known.failed(peer1.ID)
+
+	// unknown peers aren't recorded
+	known.failed(newTestAddrInfo(t).ID)
+
+	require.NoError(t, known.save())
+
+	loaded := newKnownPeers(path)
+	require.NoError(t, loaded.load())
+
+	infos := loaded.addrInfos()
+	require.Len(t, infos, 2)
+
+	// the peer without failed dials comes first
+	assert.Equal(t, &peer2, infos[0])
+	assert.Equal(t, &peer1, infos[1])
+	assert.Equal(t, uint64(1), loaded.peers[peer1.ID].Failures)
+}
+
+func TestKnownPeers_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	known := newKnownPeers(filepath.Join(t.TempDir(), knownPeersFile))
+	known.now = func() time.Time {
+		return now
+	}
+
+	peer1, peer2 := newTestAddrInfo(t), newTestAddrInfo(t)
+
+	known.connected(peer1)
+
+	now = now.Add(knownPeerTTL)
+
+	known.connected(peer2)
+
+	// the peers which haven't been seen for long are forgotten
+	now = now.Add(time.Second)
+
+	infos := known.addrInfos()
+	require.Len(t, infos, 1)
+	assert.Equal(t, peer2.ID, infos[0].ID)
+	assert.NotContains(t, known.peers, peer1.ID)
+}
@@ -0,0 +1,198 @@
+package network
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Penalty is the amount a peer's penalty score is increased by for misbehaving
+type Penalty float64
+
+const (
+	// PenaltyInvalidTx is applied for gossiping an invalid transaction
+	PenaltyInvalidTx Penalty = 10
+
+	// PenaltyInvalidConsensusMessage is applied for gossiping an invalid consensus message
+	PenaltyInvalidConsensusMessage Penalty = 25
+
+	// PenaltyInvalidBlock is applied for serving a block which fails verification
+	PenaltyInvalidBlock Penalty = 50
+)
+
+const (
+	// banThreshold is the penalty score at which a peer gets banned
+	banThreshold = 100
+
+	// penaltyHalfLife is the time it takes for a penalty score to halve
+	penaltyHalfLife = 10 * time.Minute
+
+	// minPenalty is the penalty score below which a peer is forgiven
+	minPenalty = 1
+
+	// DefaultBanDuration is the ban duration of peers reaching the ban threshold
+	DefaultBanDuration = time.Hour
+)
+
+// peerPenalty is the penalty score of a peer at the time of the last update
+type peerPenalty struct {
+	score   float64
+	updated time.Time
+}
+
+// reputation keeps track of the penalty scores and bans of peers.
+// Penalty scores decay exponentially, so only the recent misbehavior counts
+type reputation struct {
+	lock sync.Mutex
+
+	penalties map[peer.ID]*peerPenalty
+	bans      map[peer.ID]time.Time // peerID -> ban expiry
+
+	now func() time.Time
+}
+
+func newReputation() *reputation {
+	return &reputation{
+		penalties: make(map[peer.ID]*peerPenalty),
+		bans:      make(map[peer.ID]time.Time),
+		now:       time.Now,
+	}
+}
+
+// decay returns the penalty score decayed over the elapsed time
+func decay(score float64, elapsed time.Duration) float64 {
+	return score * math.Pow(0.5, float64(elapsed)/float64(penaltyHalfLife))
+}
+
+// penalize increases the penalty score of the peer.
+// The peer is banned for the default duration and true is returned
+// if the score reaches the ban threshold
+func (r *reputation) penalize(id peer.ID, penalty Penalty) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+
+	// decay the scores first, dropping the forgiven peers
+	for peerID, p := range r.penalties {
+		if p.score = decay(p.score, now.Sub(p.updated)); p.score < minPenalty {
+			delete(r.penalties, peerID)
+
+			continue
+		}
+
+		p.updated = now
+	}
+
+	p, ok := r.penalties[id]
+	if !ok {
+		p = &peerPenalty{updated: now}
+		r.penalties[id] = p
+	}
+
+	p.score += float64(penalty)
+
+	if p.score < banThreshold {
+		return false
+	}
+
+	delete(r.penalties, id)
+	r.bans[id] = now.Add(DefaultBanDuration)
+
+	return true
+}
+
+// ban bans the peer for the given duration
+func (r *reputation) ban(id peer.ID, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.penalties, id)
+	r.bans[id] = r.now().Add(duration)
+}
+
+// unban lifts the ban of the peer. Returns false if the peer wasn't banned
+func (r *reputation) unban(id peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	_, ok := r.bans[id]
+	delete(r.bans, id)
+
+	return ok
+}
+
+// isBanned checks if the peer is currently banned
+func (r *reputation) isBanned(id peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	until, ok := r.bans[id]
+	if !ok {
+		return false
+	}
+
+	if !r.now().Before(until) {
+		// the ban has expired
+		delete(r.bans, id)
+
+		return false
+	}
+
+	return true
+}
+
+// banned returns the currently banned peers along with their ban expiry
+func (r *reputation) banned() map[peer.ID]time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	banned := make(map[peer.ID]time.Time, len(r.bans))
+
+	for id, until := range r.bans {
+		if !now.Before(until) {
+			delete(r.bans, id)
+
+			continue
+		}
+
+		banned[id] = until
+	}
+
+	return banned
+}
+
+// banGater is a libp2p connection gater rejecting connections to and from banned peers
+type banGater struct {
+	reputation *reputation
+}
+
+var _ connmgr.ConnectionGater = (*banGater)(nil)
+
+func (g *banGater) InterceptPeerDial(p peer.ID) bool {
+	return !g.reputation.isBanned(p)
+}
+
+func (g *banGater) InterceptAddrDial(p peer.ID, _ multiaddr.Multiaddr) bool {
+	return !g.reputation.isBanned(p)
+}
+
+func (g *banGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	// the remote peer is not known before the handshake
+	return true
+}
+
+func (g *banGater) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return !g.reputation.isBanned(p)
+}
+
+func (g *banGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
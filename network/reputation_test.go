@@ -0,0 +1,87 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputation_Penalize(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	r := newReputation()
+	r.now = func() time.Time {
+		return now
+	}
+
+	id := peer.ID("peer")
+
+	assert.False(t, r.penalize(id, PenaltyInvalidBlock))
+	assert.False(t, r.isBanned(id))
+
+	// the penalty halves after the half-life
+	now = now.Add(penaltyHalfLife)
+
+	assert.False(t, r.penalize(id, PenaltyInvalidBlock))
+	assert.InDelta(t, 75, r.penalties[id].score, 0.001)
+
+	assert.True(t, r.penalize(id, PenaltyInvalidConsensusMessage))
+	assert.True(t, r.isBanned(id))
+	assert.NotContains(t, r.penalties, id)
+
+	// the ban expires
+	now = now.Add(DefaultBanDuration)
+
+	assert.False(t, r.isBanned(id))
+	assert.Empty(t, r.banned())
+}
+
+func TestReputation_Decay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	r := newReputation()
+	r.now = func() time.Time {
+		return now
+	}
+
+	r.penalize(peer.ID("peer1"), PenaltyInvalidTx)
+
+	// the forgiven peers are dropped
+	now = now.Add(4 * penaltyHalfLife)
+
+	r.penalize(peer.ID("peer2"), PenaltyInvalidTx)
+
+	assert.NotContains(t, r.penalties, peer.ID("peer1"))
+	assert.Contains(t, r.penalties, peer.ID("peer2"))
+}
+
+func TestReputation_BanUnban(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	r := newReputation()
+	r.now = func() time.Time {
+		return now
+	}
+
+	id := peer.ID("peer")
+	gater := &banGater{reputation: r}
+
+	assert.False(t, r.unban(id))
+
+	r.ban(id, time.Minute)
+
+	assert.False(t, gater.InterceptPeerDial(id))
+	assert.Equal(t, map[peer.ID]time.Time{id: now.Add(time.Minute)}, r.banned())
+
+	assert.True(t, r.unban(id))
+	assert.True(t, gater.InterceptPeerDial(id))
+	assert.Empty(t, r.banned())
+}
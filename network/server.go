@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -51,11 +52,18 @@ const (
 
 	MinimumBootNodes       int   = 1
 	MinimumPeerConnections int64 = 1
+
+	// staticPeerCheckInterval is the interval the static peer connections are checked at
+	staticPeerCheckInterval = 10 * time.Second
+
+	// staticPeerMaxBackoff is the maximum delay between the dials of a static peer
+	staticPeerMaxBackoff = 5 * time.Minute
 )
 
 var (
 	ErrNoBootnodes  = errors.New("no bootnodes specified")
 	ErrMinBootnodes = errors.New("minimum 1 bootnode is required")
+	ErrNoSentries   = errors.New("private mode requires at least 1 static peer")
 )
 
 type Server struct {
@@ -88,6 +96,13 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	reputation *reputation // penalty scores and bans of peers
+
+	knownPeers *knownPeers // peers saved across restarts, nil if there is no data directory
+
+	staticPeers  []*peer.AddrInfo     // peers the node always stays connected to
+	privatePeers map[peer.ID]struct{} // peers which are never shared through the discovery
 }
 
 // NewServer returns a new instance of the networking server
@@ -104,7 +119,23 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if config.PrivateMode {
+		// Private mode:
+		// - disables peer discovery, so the node neither looks for peers nor answers discovery queries
+		// - connects only to the static peers (sentries)
+		// - advertises no addresses of its own
+		if len(config.StaticPeers) == 0 {
+			return nil, ErrNoSentries
+		}
+
+		config.NoDiscover = true
+	}
+
 	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+		if config.PrivateMode {
+			return nil
+		}
+
 		if config.NatAddr != nil {
 			addr, _ := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.NatAddr.String(), config.Addr.Port))
 
@@ -118,12 +149,16 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return addrs
 	}
 
+	reputation := newReputation()
+
 	host, err := libp2p.New(
 		// Use noise as the encryption protocol
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		// Reject the connections of banned peers
+		libp2p.ConnectionGater(&banGater{reputation: reputation}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -134,11 +169,22 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	staticPeers, trustedPeers, privatePeers, err := parsePrivilegedPeers(config)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := host.Addrs()
+	if config.PrivateMode {
+		// the advertised addresses are hidden, keep the bound ones for the local use
+		addrs = host.Network().ListenAddresses()
+	}
+
 	srv := &Server{
 		logger:           logger,
 		config:           config,
 		host:             host,
-		addrs:            host.Addrs(),
+		addrs:            addrs,
 		peers:            make(map[peer.ID]*PeerConnInfo),
 		dialQueue:        dial.NewDialQueue(),
 		closeCh:          make(chan struct{}),
@@ -153,7 +199,15 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		connectionCounts: NewBlankConnectionInfo(
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
+			trustedPeers,
 		),
+		reputation:   reputation,
+		staticPeers:  staticPeers,
+		privatePeers: make(map[peer.ID]struct{}, len(privatePeers)),
+	}
+
+	for _, id := range privatePeers {
+		srv.privatePeers[id] = struct{}{}
 	}
 
 	// start gossip protocol
@@ -168,14 +222,82 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 
 	srv.ps = ps
 
+	if config.DataDir != "" {
+		srv.knownPeers = newKnownPeers(filepath.Join(config.DataDir, knownPeersFile))
+
+		if err := srv.knownPeers.load(); err != nil {
+			logger.Warn("Unable to load the known peers", "err", err)
+		}
+	}
+
 	return srv, nil
 }
 
+// parsePrivilegedPeers parses the static, trusted and private peers of the configuration.
+// The static and private peers are trusted as well
+func parsePrivilegedPeers(config *Config) ([]*peer.AddrInfo, []peer.ID, []peer.ID, error) {
+	staticPeers := make([]*peer.AddrInfo, 0, len(config.StaticPeers))
+	trustedPeers := make([]peer.ID, 0, len(config.StaticPeers)+len(config.TrustedPeers)+len(config.PrivatePeers))
+
+	for _, rawAddr := range config.StaticPeers {
+		info, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse static peer %s: %w", rawAddr, err)
+		}
+
+		staticPeers = append(staticPeers, info)
+		trustedPeers = append(trustedPeers, info.ID)
+	}
+
+	ids, err := parsePeerIDs(config.TrustedPeers)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse trusted peer %w", err)
+	}
+
+	trustedPeers = append(trustedPeers, ids...)
+
+	privatePeers, err := parsePeerIDs(config.PrivatePeers)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse private peer %w", err)
+	}
+
+	trustedPeers = append(trustedPeers, privatePeers...)
+
+	return staticPeers, trustedPeers, privatePeers, nil
+}
+
+// parsePeerIDs decodes the raw peer IDs
+func parsePeerIDs(rawIDs []string) ([]peer.ID, error) {
+	ids := make([]peer.ID, 0, len(rawIDs))
+
+	for _, rawID := range rawIDs {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rawID, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // HasFreeConnectionSlot checks if there are free connection slots in the specified direction [Thread safe]
 func (s *Server) HasFreeConnectionSlot(direction network.Direction) bool {
 	return s.connectionCounts.HasFreeConnectionSlot(direction)
 }
 
+// HasFreeConnectionSlotForPeer checks if there is a free connection slot for the peer
+// in the specified direction. Trusted peers bypass the connection limits,
+// and they are the only ones allowed if the discovery is restricted [Thread safe]
+func (s *Server) HasFreeConnectionSlotForPeer(peerID peer.ID, direction network.Direction) bool {
+	if s.isRestricted() && !s.connectionCounts.IsTrusted(peerID) {
+		return false
+	}
+
+	return s.connectionCounts.HasFreeConnectionSlotForPeer(peerID, direction)
+}
+
 // PeerConnInfo holds the connection information about the peer
 type PeerConnInfo struct {
 	Info peer.AddrInfo
@@ -266,6 +388,18 @@ func (s *Server) Start() error {
 		}
 	}
 
+	if s.knownPeers != nil {
+		// Reconnect to the peers known before the restart,
+		// so the node doesn't depend on the bootnodes being available
+		s.dialKnownPeers()
+
+		go s.runKnownPeersSaver()
+	}
+
+	for _, staticPeer := range s.staticPeers {
+		go s.keepStaticPeerConnected(staticPeer)
+	}
+
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
 
@@ -350,6 +484,36 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 	}
 }
 
+// keepStaticPeerConnected dials the static peer whenever it gets disconnected.
+// The static peers bypass the dial queue, the failed dials are retried with an exponential backoff
+func (s *Server) keepStaticPeerConnected(peerInfo *peer.AddrInfo) {
+	backoff := staticPeerCheckInterval
+
+	for {
+		delay := staticPeerCheckInterval
+
+		if !s.IsConnected(peerInfo.ID) {
+			if err := s.host.Connect(context.Background(), *peerInfo); err != nil {
+				s.logger.Debug("failed to dial static peer", "addr", peerInfo.String(), "err", err.Error())
+
+				delay = backoff
+
+				if backoff *= 2; backoff > staticPeerMaxBackoff {
+					backoff = staticPeerMaxBackoff
+				}
+			} else {
+				backoff = staticPeerCheckInterval
+			}
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
 // runDial starts the networking server's dial loop.
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
@@ -419,6 +583,10 @@ func (s *Server) runDial() {
 				if err := s.host.Connect(context.Background(), *peerInfo); err != nil {
 					s.logger.Debug("failed to dial", "addr", peerInfo.String(), "err", err.Error())
 
+					if s.knownPeers != nil {
+						s.knownPeers.failed(peerInfo.ID)
+					}
+
 					s.emitEvent(peerInfo.ID, peerEvent.PeerFailedToConnect)
 				}
 			}
@@ -434,6 +602,43 @@ func (s *Server) runDial() {
 	}
 }
 
+// dialKnownPeers adds the peers saved before the restart to the dial queue, best first
+func (s *Server) dialKnownPeers() {
+	infos := s.knownPeers.addrInfos()
+
+	s.logger.Info("Dialing known peers", "count", len(infos))
+
+	for _, info := range infos {
+		if info.ID == s.host.ID() {
+			continue
+		}
+
+		s.addToDialQueue(info, common.PriorityRandomDial)
+	}
+}
+
+// runKnownPeersSaver periodically saves the known peers to the data directory
+func (s *Server) runKnownPeersSaver() {
+	ticker := time.NewTicker(knownPeersSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.saveKnownPeers()
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// saveKnownPeers saves the known peers to the data directory
+func (s *Server) saveKnownPeers() {
+	if err := s.knownPeers.save(); err != nil {
+		s.logger.Error("Unable to save the known peers", "err", err)
+	}
+}
+
 // numPeers returns the number of connected peers [Thread safe]
 func (s *Server) numPeers() int64 {
 	s.peersLock.Lock()
@@ -557,6 +762,62 @@ func (s *Server) DisconnectFromPeer(peer peer.ID, reason string) {
 	}
 }
 
+// PenalizePeer increases the penalty score of the peer for misbehaving.
+// The peer is disconnected and banned if its score reaches the ban threshold
+func (s *Server) PenalizePeer(peerID peer.ID, penalty Penalty, reason string) {
+	s.logger.Debug("Penalizing peer", "id", peerID, "penalty", penalty, "reason", reason)
+
+	metrics.IncrCounter([]string{networkMetrics, "peer_penalties"}, 1)
+
+	if s.reputation.penalize(peerID, penalty) {
+		s.logger.Info("Peer reached the ban threshold", "id", peerID, "reason", reason)
+
+		s.banPeer(peerID, reason)
+	}
+}
+
+// BanPeer disconnects the peer and rejects its connections for the given duration
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) {
+	s.reputation.ban(peerID, duration)
+	s.banPeer(peerID, reason)
+}
+
+// banPeer drops the pending dials and the connection of the banned peer
+func (s *Server) banPeer(peerID peer.ID, reason string) {
+	metrics.IncrCounter([]string{networkMetrics, "banned_peers"}, 1)
+
+	s.dialQueue.DeleteTask(peerID)
+	s.DisconnectFromPeer(peerID, reason)
+}
+
+// UnbanPeer lifts the ban of the peer. Returns false if the peer wasn't banned
+func (s *Server) UnbanPeer(peerID peer.ID) bool {
+	return s.reputation.unban(peerID)
+}
+
+// IsBanned checks if the peer is currently banned
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.reputation.isBanned(peerID)
+}
+
+// IsPrivatePeer checks if the peer must not be shared with other peers through the discovery
+func (s *Server) IsPrivatePeer(peerID peer.ID) bool {
+	_, ok := s.privatePeers[peerID]
+
+	return ok
+}
+
+// isRestricted checks if only the trusted peers can be connected to.
+// A node in the private mode connects only to its sentries, which are trusted
+func (s *Server) isRestricted() bool {
+	return s.config.RestrictDiscovery || s.config.PrivateMode
+}
+
+// BannedPeers returns the currently banned peers along with their ban expiry
+func (s *Server) BannedPeers() map[peer.ID]time.Time {
+	return s.reputation.banned()
+}
+
 var (
 	// Anything below 35s is prone to false timeouts, as seen from empirical test data
 	DefaultJoinTimeout   = 100 * time.Second
@@ -598,6 +859,10 @@ func (s *Server) Close() error {
 	err := s.host.Close()
 	s.dialQueue.Close()
 
+	if s.knownPeers != nil {
+		s.saveKnownPeers()
+	}
+
 	if !s.config.NoDiscover {
 		s.discovery.Close()
 	}
@@ -660,6 +925,18 @@ func (s *Server) AddrInfo() *peer.AddrInfo {
 }
 
 func (s *Server) addToDialQueue(addr *peer.AddrInfo, priority common.DialPriority) {
+	if s.IsBanned(addr.ID) {
+		s.logger.Debug("Omitting banned peer from the dial queue", "id", addr.ID)
+
+		return
+	}
+
+	if s.isRestricted() && !s.connectionCounts.IsTrusted(addr.ID) {
+		s.logger.Debug("Omitting untrusted peer from the dial queue", "id", addr.ID)
+
+		return
+	}
+
 	s.dialQueue.AddTask(addr, priority)
 	s.emitEvent(addr.ID, peerEvent.PeerAddedToDialQueue)
 }
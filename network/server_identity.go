@@ -40,6 +40,10 @@ func (s *Server) AddPeer(id peer.ID, direction network.Direction) {
 		return
 	}
 
+	if s.knownPeers != nil {
+		s.knownPeers.connected(s.host.Peerstore().PeerInfo(id))
+	}
+
 	// Emit the event alerting listeners
 	// WARNING: THIS CALL IS POTENTIALLY BLOCKING
 	s.emitEvent(id, peerEvent.PeerConnected)
@@ -1153,3 +1153,40 @@ func generateRandomPeers(t *testing.T, count int) ([]*randomPeer, error) {
 
 	return randomPeers, nil
 }
+
+func TestParsePrivilegedPeers(t *testing.T) {
+	t.Parallel()
+
+	staticAddr := tests.GenerateTestMultiAddr(t)
+	staticInfo, err := peer.AddrInfoFromP2pAddr(staticAddr)
+	assert.NoError(t, err)
+
+	trustedInfo, err := peer.AddrInfoFromP2pAddr(tests.GenerateTestMultiAddr(t))
+	assert.NoError(t, err)
+
+	privateInfo, err := peer.AddrInfoFromP2pAddr(tests.GenerateTestMultiAddr(t))
+	assert.NoError(t, err)
+
+	staticPeers, trustedPeers, privatePeers, err := parsePrivilegedPeers(&Config{
+		StaticPeers:  []string{staticAddr.String()},
+		TrustedPeers: []string{trustedInfo.ID.String()},
+		PrivatePeers: []string{privateInfo.ID.String()},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*peer.AddrInfo{staticInfo}, staticPeers)
+	assert.Equal(t, []peer.ID{privateInfo.ID}, privatePeers)
+
+	// static and private peers are trusted as well
+	assert.Equal(t, []peer.ID{staticInfo.ID, trustedInfo.ID, privateInfo.ID}, trustedPeers)
+
+	_, _, _, err = parsePrivilegedPeers(&Config{
+		TrustedPeers: []string{"invalid"},
+	})
+	assert.Error(t, err)
+
+	_, _, _, err = parsePrivilegedPeers(&Config{
+		PrivatePeers: []string{"invalid"},
+	})
+	assert.Error(t, err)
+}
@@ -40,6 +40,7 @@ type MockNetworkingServer struct {
 	removeFromPeerStoreFn      removeFromPeerStoreDelegate
 	getPeerInfoFn              getPeerInfoDelegate
 	getRandomPeerFn            getRandomPeerDelegate
+	isPrivatePeerFn            isPrivatePeerDelegate
 	fetchAndSetTemporaryDialFn fetchAndSetTemporaryDialDelegate
 	removeTemporaryDialFn      removeTemporaryDialDelegate
 	temporaryDialPeerFn        temporaryDialPeerDelegate
@@ -84,6 +85,7 @@ type addToPeerStoreDelegate func(*peer.AddrInfo)
 type removeFromPeerStoreDelegate func(peerInfo *peer.AddrInfo)
 type getPeerInfoDelegate func(peer.ID) *peer.AddrInfo
 type getRandomPeerDelegate func() *peer.ID
+type isPrivatePeerDelegate func(peer.ID) bool
 type fetchAndSetTemporaryDialDelegate func(peer.ID, bool) bool
 type removeTemporaryDialDelegate func(peer.ID)
 type temporaryDialPeerDelegate func(peerAddrInfo *peer.AddrInfo)
@@ -174,6 +176,10 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) HasFreeConnectionSlotForPeer(_ peer.ID, direction network.Direction) bool {
+	return m.HasFreeConnectionSlot(direction)
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
@@ -266,6 +272,18 @@ func (m *MockNetworkingServer) HookGetRandomPeer(fn getRandomPeerDelegate) {
 	m.getRandomPeerFn = fn
 }
 
+func (m *MockNetworkingServer) IsPrivatePeer(peerID peer.ID) bool {
+	if m.isPrivatePeerFn != nil {
+		return m.isPrivatePeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsPrivatePeer(fn isPrivatePeerDelegate) {
+	m.isPrivatePeerFn = fn
+}
+
 func (m *MockNetworkingServer) FetchOrSetTemporaryDial(peerID peer.ID, newValue bool) bool {
 	if m.fetchAndSetTemporaryDialFn != nil {
 		return m.fetchAndSetTemporaryDialFn(peerID, newValue)
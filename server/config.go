@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 )
 
 const DefaultGRPCPort int = 9632
@@ -24,6 +25,10 @@ type Config struct {
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	PriceBump          uint64
+	MaxAccountPromoted uint64
+	PeerTxRateLimit    uint64
+	RPCTxRateLimit     uint64
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -44,6 +49,12 @@ type Config struct {
 	Relayer bool
 
 	NumBlockConfirmations uint64
+
+	// StatePruning is the configuration of the state pruning, the state is archived if not set
+	StatePruning *itrie.PruningConfig
+
+	// StateSnapshot enables the flat snapshot of the latest states
+	StateSnapshot bool
 }
 
 // Telemetry holds the config details for metric services
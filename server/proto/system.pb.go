@@ -620,6 +620,212 @@ func (x *ExportEvent) GetData() []byte {
 	return nil
 }
 
+type PeersBanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ban duration in seconds, the default ban duration is used if zero
+	Duration uint64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *PeersBanRequest) Reset() {
+	*x = PeersBanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersBanRequest) ProtoMessage() {}
+
+func (x *PeersBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersBanRequest.ProtoReflect.Descriptor instead.
+func (*PeersBanRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PeersBanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeersBanRequest) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type PeersUnbanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PeersUnbanRequest) Reset() {
+	*x = PeersUnbanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersUnbanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersUnbanRequest) ProtoMessage() {}
+
+func (x *PeersUnbanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersUnbanRequest.ProtoReflect.Descriptor instead.
+func (*PeersUnbanRequest) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PeersUnbanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BannedPeer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// unix time (in seconds) the ban expires at
+	Until int64 `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (x *BannedPeer) Reset() {
+	*x = BannedPeer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BannedPeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPeer) ProtoMessage() {}
+
+func (x *BannedPeer) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPeer.ProtoReflect.Descriptor instead.
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BannedPeer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BannedPeer) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+type PeersListBannedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peers []*BannedPeer `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (x *PeersListBannedResponse) Reset() {
+	*x = PeersListBannedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_proto_system_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersListBannedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersListBannedResponse) ProtoMessage() {}
+
+func (x *PeersListBannedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_proto_system_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersListBannedResponse.ProtoReflect.Descriptor instead.
+func (*PeersListBannedResponse) Descriptor() ([]byte, []int) {
+	return file_server_proto_system_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PeersListBannedResponse) GetPeers() []*BannedPeer {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
 type BlockchainEvent_Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -632,7 +838,7 @@ type BlockchainEvent_Header struct {
 func (x *BlockchainEvent_Header) Reset() {
 	*x = BlockchainEvent_Header{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[11]
+		mi := &file_server_proto_system_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -645,7 +851,7 @@ func (x *BlockchainEvent_Header) String() string {
 func (*BlockchainEvent_Header) ProtoMessage() {}
 
 func (x *BlockchainEvent_Header) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[11]
+	mi := &file_server_proto_system_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -687,7 +893,7 @@ type ServerStatus_Block struct {
 func (x *ServerStatus_Block) Reset() {
 	*x = ServerStatus_Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_proto_system_proto_msgTypes[12]
+		mi := &file_server_proto_system_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -700,7 +906,7 @@ func (x *ServerStatus_Block) String() string {
 func (*ServerStatus_Block) ProtoMessage() {}
 
 func (x *ServerStatus_Block) ProtoReflect() protoreflect.Message {
-	mi := &file_server_proto_system_proto_msgTypes[12]
+	mi := &file_server_proto_system_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,34 +1002,62 @@ var file_server_proto_system_proto_rawDesc = []byte{
 	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16,
 	0x0a, 0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
 	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x8d, 0x03, 0x0a, 0x06, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x35, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x08,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2f, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x12, 0x3a, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x2e,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x57, 0x0a, 0x0f, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xfa, 0x42, 0x15, 0x72, 0x13,
+	0x32, 0x11, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31,
+	0x2c, 0x7d, 0x24, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xfa, 0x42, 0x15, 0x72, 0x13, 0x32, 0x11, 0x5e, 0x5b, 0x41,
+	0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x31, 0x2c, 0x7d, 0x24, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x32, 0x0a, 0x0a, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x3f, 0x0a, 0x17, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72,
+	0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x32, 0xcb, 0x04, 0x0a, 0x06, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x35, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0d,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x45, 0x78,
-	0x70, 0x6f, 0x72, 0x74, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0b,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x12, 0x37, 0x0a,
+	0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0a, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55,
+	0x6e, 0x62, 0x61, 0x6e, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55,
+	0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x6e,
+	0x6e, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x42, 0x79, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x11, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -838,47 +1072,58 @@ func file_server_proto_system_proto_rawDescGZIP() []byte {
 	return file_server_proto_system_proto_rawDescData
 }
 
-var file_server_proto_system_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_server_proto_system_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_server_proto_system_proto_goTypes = []interface{}{
-	(*BlockchainEvent)(nil),        // 0: v1.BlockchainEvent
-	(*ServerStatus)(nil),           // 1: v1.ServerStatus
-	(*Peer)(nil),                   // 2: v1.Peer
-	(*PeersAddRequest)(nil),        // 3: v1.PeersAddRequest
-	(*PeersAddResponse)(nil),       // 4: v1.PeersAddResponse
-	(*PeersStatusRequest)(nil),     // 5: v1.PeersStatusRequest
-	(*PeersListResponse)(nil),      // 6: v1.PeersListResponse
-	(*BlockByNumberRequest)(nil),   // 7: v1.BlockByNumberRequest
-	(*BlockResponse)(nil),          // 8: v1.BlockResponse
-	(*ExportRequest)(nil),          // 9: v1.ExportRequest
-	(*ExportEvent)(nil),            // 10: v1.ExportEvent
-	(*BlockchainEvent_Header)(nil), // 11: v1.BlockchainEvent.Header
-	(*ServerStatus_Block)(nil),     // 12: v1.ServerStatus.Block
-	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
+	(*BlockchainEvent)(nil),         // 0: v1.BlockchainEvent
+	(*ServerStatus)(nil),            // 1: v1.ServerStatus
+	(*Peer)(nil),                    // 2: v1.Peer
+	(*PeersAddRequest)(nil),         // 3: v1.PeersAddRequest
+	(*PeersAddResponse)(nil),        // 4: v1.PeersAddResponse
+	(*PeersStatusRequest)(nil),      // 5: v1.PeersStatusRequest
+	(*PeersListResponse)(nil),       // 6: v1.PeersListResponse
+	(*BlockByNumberRequest)(nil),    // 7: v1.BlockByNumberRequest
+	(*BlockResponse)(nil),           // 8: v1.BlockResponse
+	(*ExportRequest)(nil),           // 9: v1.ExportRequest
+	(*ExportEvent)(nil),             // 10: v1.ExportEvent
+	(*PeersBanRequest)(nil),         // 11: v1.PeersBanRequest
+	(*PeersUnbanRequest)(nil),       // 12: v1.PeersUnbanRequest
+	(*BannedPeer)(nil),              // 13: v1.BannedPeer
+	(*PeersListBannedResponse)(nil), // 14: v1.PeersListBannedResponse
+	(*BlockchainEvent_Header)(nil),  // 15: v1.BlockchainEvent.Header
+	(*ServerStatus_Block)(nil),      // 16: v1.ServerStatus.Block
+	(*emptypb.Empty)(nil),           // 17: google.protobuf.Empty
 }
 var file_server_proto_system_proto_depIdxs = []int32{
-	11, // 0: v1.BlockchainEvent.added:type_name -> v1.BlockchainEvent.Header
-	11, // 1: v1.BlockchainEvent.removed:type_name -> v1.BlockchainEvent.Header
-	12, // 2: v1.ServerStatus.current:type_name -> v1.ServerStatus.Block
+	15, // 0: v1.BlockchainEvent.added:type_name -> v1.BlockchainEvent.Header
+	15, // 1: v1.BlockchainEvent.removed:type_name -> v1.BlockchainEvent.Header
+	16, // 2: v1.ServerStatus.current:type_name -> v1.ServerStatus.Block
 	2,  // 3: v1.PeersListResponse.peers:type_name -> v1.Peer
-	13, // 4: v1.System.GetStatus:input_type -> google.protobuf.Empty
-	3,  // 5: v1.System.PeersAdd:input_type -> v1.PeersAddRequest
-	13, // 6: v1.System.PeersList:input_type -> google.protobuf.Empty
-	5,  // 7: v1.System.PeersStatus:input_type -> v1.PeersStatusRequest
-	13, // 8: v1.System.Subscribe:input_type -> google.protobuf.Empty
-	7,  // 9: v1.System.BlockByNumber:input_type -> v1.BlockByNumberRequest
-	9,  // 10: v1.System.Export:input_type -> v1.ExportRequest
-	1,  // 11: v1.System.GetStatus:output_type -> v1.ServerStatus
-	4,  // 12: v1.System.PeersAdd:output_type -> v1.PeersAddResponse
-	6,  // 13: v1.System.PeersList:output_type -> v1.PeersListResponse
-	2,  // 14: v1.System.PeersStatus:output_type -> v1.Peer
-	0,  // 15: v1.System.Subscribe:output_type -> v1.BlockchainEvent
-	8,  // 16: v1.System.BlockByNumber:output_type -> v1.BlockResponse
-	10, // 17: v1.System.Export:output_type -> v1.ExportEvent
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 4: v1.PeersListBannedResponse.peers:type_name -> v1.BannedPeer
+	17, // 5: v1.System.GetStatus:input_type -> google.protobuf.Empty
+	3,  // 6: v1.System.PeersAdd:input_type -> v1.PeersAddRequest
+	17, // 7: v1.System.PeersList:input_type -> google.protobuf.Empty
+	5,  // 8: v1.System.PeersStatus:input_type -> v1.PeersStatusRequest
+	11, // 9: v1.System.PeersBan:input_type -> v1.PeersBanRequest
+	12, // 10: v1.System.PeersUnban:input_type -> v1.PeersUnbanRequest
+	17, // 11: v1.System.PeersListBanned:input_type -> google.protobuf.Empty
+	17, // 12: v1.System.Subscribe:input_type -> google.protobuf.Empty
+	7,  // 13: v1.System.BlockByNumber:input_type -> v1.BlockByNumberRequest
+	9,  // 14: v1.System.Export:input_type -> v1.ExportRequest
+	1,  // 15: v1.System.GetStatus:output_type -> v1.ServerStatus
+	4,  // 16: v1.System.PeersAdd:output_type -> v1.PeersAddResponse
+	6,  // 17: v1.System.PeersList:output_type -> v1.PeersListResponse
+	2,  // 18: v1.System.PeersStatus:output_type -> v1.Peer
+	17, // 19: v1.System.PeersBan:output_type -> google.protobuf.Empty
+	17, // 20: v1.System.PeersUnban:output_type -> google.protobuf.Empty
+	14, // 21: v1.System.PeersListBanned:output_type -> v1.PeersListBannedResponse
+	0,  // 22: v1.System.Subscribe:output_type -> v1.BlockchainEvent
+	8,  // 23: v1.System.BlockByNumber:output_type -> v1.BlockResponse
+	10, // 24: v1.System.Export:output_type -> v1.ExportEvent
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_server_proto_system_proto_init() }
@@ -1020,7 +1265,7 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockchainEvent_Header); i {
+			switch v := v.(*PeersBanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1032,6 +1277,54 @@ func file_server_proto_system_proto_init() {
 			}
 		}
 		file_server_proto_system_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersUnbanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BannedPeer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersListBannedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockchainEvent_Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_proto_system_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerStatus_Block); i {
 			case 0:
 				return &v.state
@@ -1050,7 +1343,7 @@ func file_server_proto_system_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_proto_system_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
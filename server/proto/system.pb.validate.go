@@ -1320,6 +1320,475 @@ var _ interface {
 	ErrorName() string
 } = ExportEventValidationError{}
 
+// Validate checks the field values on PeersBanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersBanRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersBanRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersBanRequestMultiError, or nil if none found.
+func (m *PeersBanRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersBanRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_PeersBanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersBanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Duration
+
+	if len(errors) > 0 {
+		return PeersBanRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersBanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersBanRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersBanRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersBanRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersBanRequestMultiError) AllErrors() []error { return m }
+
+// PeersBanRequestValidationError is the validation error returned by
+// PeersBanRequest.Validate if the designated constraints aren't met.
+type PeersBanRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersBanRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersBanRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersBanRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersBanRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersBanRequestValidationError) ErrorName() string { return "PeersBanRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PeersBanRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersBanRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersBanRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersBanRequestValidationError{}
+
+var _PeersBanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on PeersUnbanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersUnbanRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersUnbanRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersUnbanRequestMultiError, or nil if none found.
+func (m *PeersUnbanRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersUnbanRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_PeersUnbanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersUnbanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PeersUnbanRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersUnbanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersUnbanRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersUnbanRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersUnbanRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersUnbanRequestMultiError) AllErrors() []error { return m }
+
+// PeersUnbanRequestValidationError is the validation error returned by
+// PeersUnbanRequest.Validate if the designated constraints aren't met.
+type PeersUnbanRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersUnbanRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersUnbanRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersUnbanRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersUnbanRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersUnbanRequestValidationError) ErrorName() string {
+	return "PeersUnbanRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersUnbanRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersUnbanRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersUnbanRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersUnbanRequestValidationError{}
+
+var _PeersUnbanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on BannedPeer with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BannedPeer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BannedPeer with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BannedPeerMultiError, or
+// nil if none found.
+func (m *BannedPeer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BannedPeer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Until
+
+	if len(errors) > 0 {
+		return BannedPeerMultiError(errors)
+	}
+
+	return nil
+}
+
+// BannedPeerMultiError is an error wrapping multiple validation errors
+// returned by BannedPeer.ValidateAll() if the designated constraints aren't met.
+type BannedPeerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BannedPeerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BannedPeerMultiError) AllErrors() []error { return m }
+
+// BannedPeerValidationError is the validation error returned by
+// BannedPeer.Validate if the designated constraints aren't met.
+type BannedPeerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BannedPeerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BannedPeerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BannedPeerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BannedPeerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BannedPeerValidationError) ErrorName() string { return "BannedPeerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BannedPeerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBannedPeer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BannedPeerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BannedPeerValidationError{}
+
+// Validate checks the field values on PeersListBannedResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PeersListBannedResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersListBannedResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersListBannedResponseMultiError, or nil if none found.
+func (m *PeersListBannedResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersListBannedResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetPeers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeersListBannedResponseValidationError{
+						field:  fmt.Sprintf("Peers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeersListBannedResponseValidationError{
+						field:  fmt.Sprintf("Peers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeersListBannedResponseValidationError{
+					field:  fmt.Sprintf("Peers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PeersListBannedResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersListBannedResponseMultiError is an error wrapping multiple validation
+// errors returned by PeersListBannedResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PeersListBannedResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersListBannedResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersListBannedResponseMultiError) AllErrors() []error { return m }
+
+// PeersListBannedResponseValidationError is the validation error returned by
+// PeersListBannedResponse.Validate if the designated constraints aren't met.
+type PeersListBannedResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersListBannedResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersListBannedResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersListBannedResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersListBannedResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersListBannedResponseValidationError) ErrorName() string {
+	return "PeersListBannedResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersListBannedResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersListBannedResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersListBannedResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersListBannedResponseValidationError{}
+
 // Validate checks the field values on BlockchainEvent_Header with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -25,6 +25,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/gasprice"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	configHelper "github.com/0xPolygon/polygon-edge/helper/config"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -43,6 +44,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goleveldb "github.com/syndtr/goleveldb/leveldb"
 	"github.com/umbracle/ethgo"
 	"google.golang.org/grpc"
 )
@@ -58,6 +60,8 @@ type Server struct {
 	config       *Config
 	state        state.State
 	stateStorage itrie.Storage
+	statePruning *itrie.PruningStorage
+	flatState    *itrie.FlatState
 
 	consensus consensus.Consensus
 
@@ -153,6 +157,7 @@ func NewServer(config *Config) (*Server, error) {
 	var dirPaths = []string{
 		"blockchain",
 		"trie",
+		"txpool",
 	}
 
 	// Generate all the paths in the dataDir
@@ -199,6 +204,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.config.StatePruning != nil {
+		m.statePruning = itrie.NewPruningStorage(stateStorage, *m.config.StatePruning, logger)
+		stateStorage = m.statePruning
+	}
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
@@ -277,6 +287,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.statePruning != nil {
+		// the genesis state is always flushed
+		m.statePruning.CommitRoot(0, genesisRoot)
+	}
+
 	// compute the genesis root state
 	config.Chain.Genesis.StateRoot = genesisRoot
 
@@ -335,6 +350,11 @@ func NewServer(config *Config) (*Server, error) {
 			return nil, err
 		}
 
+		var journalPath string
+		if m.config.DataDir != "" {
+			journalPath = filepath.Join(m.config.DataDir, "txpool", "journal")
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -346,7 +366,12 @@ func NewServer(config *Config) (*Server, error) {
 				MaxSlots:            m.config.MaxSlots,
 				PriceLimit:          m.config.PriceLimit,
 				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
+				PriceBump:           m.config.PriceBump,
+				MaxAccountPromoted:  m.config.MaxAccountPromoted,
+				PeerTxRateLimit:     m.config.PeerTxRateLimit,
+				RPCTxRateLimit:      m.config.RPCTxRateLimit,
 				DeploymentWhitelist: deploymentWhitelist,
+				JournalPath:         journalPath,
 			},
 		)
 		if err != nil {
@@ -376,6 +401,19 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the lost states are regenerated once the consensus hooks are initialized
+	if m.statePruning != nil {
+		if err := m.setupStatePruning(); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.config.StateSnapshot {
+		if err := m.setupFlatState(st); err != nil {
+			return nil, err
+		}
+	}
+
 	// setup and start grpc server
 	if err := m.setupGRPC(); err != nil {
 		return nil, err
@@ -407,7 +445,9 @@ func NewServer(config *Config) (*Server, error) {
 		}
 	}
 
-	m.txpool.Start()
+	if err := m.txpool.Start(); err != nil {
+		return nil, err
+	}
 
 	return m, nil
 }
@@ -657,6 +697,7 @@ type jsonRPCHub struct {
 	*network.Server
 	consensus.Consensus
 	consensus.BridgeDataProvider
+	gasprice.GasStore
 }
 
 func (j *jsonRPCHub) GetPeers() int {
@@ -712,6 +753,20 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the merkle proof of the account and of its storage keys at the given state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	storageKeys []types.Hash,
+) (*state.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	return snap.GetProof(addr, storageKeys)
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -876,6 +931,11 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
+	gasHelper, err := gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, s.blockchain)
+	if err != nil {
+		return err
+	}
+
 	hub := &jsonRPCHub{
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
@@ -885,6 +945,7 @@ func (s *Server) setupJSONRPC() error {
 		Consensus:          s.consensus,
 		Server:             s.network,
 		BridgeDataProvider: s.consensus.GetBridgeProvider(),
+		GasStore:           gasHelper,
 	}
 
 	conf := &jsonrpc.Config{
@@ -934,6 +995,112 @@ func (s *Server) Chain() *chain.Chain {
 	return s.chain
 }
 
+// setupStatePruning regenerates the states lost after the last flush by re-executing
+// the canonical blocks, and retains the state roots of the new heads in the pruning storage
+func (s *Server) setupStatePruning() error {
+	head := s.blockchain.Header()
+
+	// the states after the last flush are lost if the node wasn't shut down cleanly,
+	// the genesis state is always available
+	number := head.Number
+
+	for ; number > 0; number-- {
+		header, ok := s.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return fmt.Errorf("failed to get header of block %d", number)
+		}
+
+		if _, err := s.state.NewSnapshotAt(header.StateRoot); err == nil {
+			break
+		}
+	}
+
+	if number != head.Number {
+		s.logger.Warn("Regenerating the states lost after the last flush",
+			"head", head.Number, "block", number)
+	}
+
+	// the head is kept, as the blocks are final on the BFT chains
+	for number++; number <= head.Number; number++ {
+		block, ok := s.blockchain.GetBlockByNumber(number, true)
+		if !ok {
+			return fmt.Errorf("failed to get block %d", number)
+		}
+
+		if err := s.blockchain.RegenerateState(block); err != nil {
+			return fmt.Errorf("failed to regenerate the state of block %d: %w", number, err)
+		}
+
+		s.statePruning.CommitRoot(number, block.Header.StateRoot)
+	}
+
+	sub := s.blockchain.SubscribeEvents()
+
+	go func() {
+		for {
+			evnt := sub.GetEvent()
+			if evnt == nil {
+				return
+			}
+
+			if evnt.Type == blockchain.EventFork {
+				continue
+			}
+
+			// the new chain is ordered new head first
+			for i := len(evnt.NewChain) - 1; i >= 0; i-- {
+				s.statePruning.CommitRoot(evnt.NewChain[i].Number, evnt.NewChain[i].StateRoot)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// setupFlatState opens the flat snapshot of the head state,
+// and merges the diff layers below the new heads into its disk layer
+func (s *Server) setupFlatState(st *itrie.State) error {
+	db, err := goleveldb.OpenFile(filepath.Join(s.config.DataDir, "flatstate"), nil)
+	if err != nil {
+		return err
+	}
+
+	s.flatState, err = itrie.NewFlatState(
+		db,
+		s.stateStorage,
+		s.blockchain.Header().StateRoot,
+		itrie.DefaultFlatStateRetain,
+		s.logger,
+	)
+	if err != nil {
+		db.Close()
+
+		return err
+	}
+
+	st.SetFlatState(s.flatState)
+
+	sub := s.blockchain.SubscribeEvents()
+
+	go func() {
+		for {
+			evnt := sub.GetEvent()
+			if evnt == nil {
+				return
+			}
+
+			if evnt.Type == blockchain.EventFork || len(evnt.NewChain) == 0 {
+				continue
+			}
+
+			// the new chain is ordered newest first, only its head is capped
+			s.flatState.Cap(evnt.NewChain[0].StateRoot)
+		}
+	}()
+
+	return nil
+}
+
 // JoinPeer attempts to add a new peer to the networking server
 func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
@@ -956,6 +1123,13 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Close the flat state, its generator reads the state storage
+	if s.flatState != nil {
+		if err := s.flatState.Close(); err != nil {
+			s.logger.Error("failed to close flat state", "err", err.Error())
+		}
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -5,8 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -161,6 +164,60 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersBan implements the 'peers ban' operator service
+func (s *systemService) PeersBan(_ context.Context, req *proto.PeersBanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := network.DefaultBanDuration
+	if req.Duration != 0 {
+		duration = time.Duration(req.Duration) * time.Second
+	}
+
+	s.server.network.BanPeer(peerID, duration, "banned by the operator")
+
+	return &empty.Empty{}, nil
+}
+
+// PeersUnban implements the 'peers unban' operator service
+func (s *systemService) PeersUnban(_ context.Context, req *proto.PeersUnbanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.server.network.UnbanPeer(peerID) {
+		return nil, fmt.Errorf("peer %s is not banned", peerID)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersListBanned implements the 'peers list-banned' operator service
+func (s *systemService) PeersListBanned(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.PeersListBannedResponse, error) {
+	resp := &proto.PeersListBannedResponse{
+		Peers: []*proto.BannedPeer{},
+	}
+
+	for id, until := range s.server.network.BannedPeers() {
+		resp.Peers = append(resp.Peers, &proto.BannedPeer{
+			Id:    id.String(),
+			Until: until.Unix(),
+		})
+	}
+
+	sort.Slice(resp.Peers, func(i, j int) bool {
+		return resp.Peers[i].Until < resp.Peers[j].Until
+	})
+
+	return resp, nil
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
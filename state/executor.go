@@ -22,10 +22,12 @@ import (
 
 const (
 	SpuriousDragonMaxCodeSize = 24576
-	TxPoolMaxInitCodeSize     = 2 * SpuriousDragonMaxCodeSize
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP-2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP-2930 access list
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -331,7 +333,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type == types.AccessListTx || txn.Type == types.DynamicFeeTx) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -467,6 +469,15 @@ func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	return nil
 }
 
+// checkTxType checks if the transaction type is supported by the active forks
+func (t *Transition) checkTxType(msg *types.Transaction) error {
+	if msg.Type == types.AccessListTx && !t.config.Berlin {
+		return fmt.Errorf("%w: %s", ErrTxTypeNotSupported, msg.Type)
+	}
+
+	return nil
+}
+
 // checkDynamicFees checks correctness of the EIP-1559 feature-related fields.
 // Basically, makes sure gas tip cap and gas fee cap are good.
 func (t *Transition) checkDynamicFees(msg *types.Transaction) error {
@@ -512,6 +523,7 @@ var (
 	ErrBlockLimitReached     = fmt.Errorf("gas limit reached in the pool")
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
+	ErrTxTypeNotSupported    = fmt.Errorf("transaction type not supported")
 
 	// ErrTipAboveFeeCap is a sanity error to ensure no one is able to specify a
 	// transaction with a tip higher than the total fee cap.
@@ -559,6 +571,14 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	// the transactions can be applied back to back without Write (e.g. when tracing),
+	// so the scope of the previous transaction is dropped before executing this one
+	t.state.ClearTxScope()
+
+	if stateTracer, ok := t.ctx.Tracer.(tracer.TxStateTracer); ok {
+		stateTracer.TxPreState(t, t.txAccounts(msg))
+	}
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -579,7 +599,7 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	}
 
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Shanghai)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
@@ -598,6 +618,11 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	// pre-warm the addresses and storage slots accessed by the transaction (EIP-2929, EIP-2930)
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -646,6 +671,43 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// txAccounts returns the accounts touched by the transaction regardless of its execution
+func (t *Transition) txAccounts(msg *types.Transaction) []types.Address {
+	to := crypto.CreateAddress(msg.From, t.state.GetNonce(msg.From))
+	if !msg.IsContractCreation() {
+		to = *msg.To
+	}
+
+	return []types.Address{msg.From, to, t.ctx.Coinbase}
+}
+
+// prepareAccessList adds the sender, the recipient, the precompiled contracts
+// and the access list of the transaction to the access list of the state
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.AddAddressToAccessList(msg.From)
+
+	// the coinbase is warm from the start of the transaction (EIP-3651)
+	if t.config.Shanghai {
+		t.state.AddAddressToAccessList(t.ctx.Coinbase)
+	}
+
+	if msg.To != nil {
+		t.state.AddAddressToAccessList(*msg.To)
+	}
+
+	for _, addr := range t.precompiles.Addresses(&t.config) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range msg.AccessList {
+		t.state.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			t.state.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -816,6 +878,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The created address is added to the access list even if the creation fails (EIP-2929)
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -827,6 +894,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Take snapshot of the current state
 	snapshot := t.state.Snapshot()
 
+	// The account can be self-destructed only within the creating transaction (EIP-6780)
+	if t.config.Cancun {
+		t.state.MarkCreatedInTx(c.Address)
+	}
+
 	if t.config.EIP158 {
 		// Force the creation of the account
 		t.state.CreateAccount(c.Address)
@@ -843,7 +915,12 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 
 	var result *runtime.ExecutionResult
 
-	t.captureCallStart(c, evm.CREATE)
+	callType := evm.CREATE
+	if c.Type == runtime.Create2 {
+		callType = evm.CREATE2
+	}
+
+	t.captureCallStart(c, runtime.CallType(callType))
 
 	defer func() {
 		// pass result to be set later
@@ -855,19 +932,23 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		role := t.deploymentAllowList.GetRole(c.Caller)
 
 		if !role.Enabled() {
-			return &runtime.ExecutionResult{
+			result = &runtime.ExecutionResult{
 				GasLeft: 0,
 				Err:     runtime.ErrNotAuth,
 			}
+
+			return result
 		}
 	} else if t.deploymentBlockList != nil {
 		role := t.deploymentBlockList.GetRole(c.Caller)
 
 		if role == addresslist.EnabledRole {
-			return &runtime.ExecutionResult{
+			result = &runtime.ExecutionResult{
 				GasLeft: 0,
 				Err:     runtime.ErrNotAuth,
 			}
+
+			return result
 		}
 	}
 
@@ -882,10 +963,12 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		// Contract size exceeds 'SpuriousDragon' size limit
 		t.state.RevertToSnapshot(snapshot)
 
-		return &runtime.ExecutionResult{
+		result = &runtime.ExecutionResult{
 			GasLeft: 0,
 			Err:     runtime.ErrMaxCodeSizeExceeded,
 		}
+
+		return result
 	}
 
 	gasCost := uint64(len(result.ReturnValue)) * 200
@@ -969,6 +1052,18 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
+	// Accounts which were not created in the same transaction only send their balance (EIP-6780)
+	if t.config.Cancun && !t.state.CreatedInTx(addr) {
+		if addr != beneficiary {
+			balance := new(big.Int).Set(t.state.GetBalance(addr))
+
+			t.state.SetBalance(addr, big.NewInt(0))
+			t.state.AddBalance(beneficiary, balance)
+		}
+
+		return
+	}
+
 	if !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
@@ -978,7 +1073,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1030,7 +1125,31 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
+func (t *Transition) ContainsAccessListAddress(addr types.Address) bool {
+	return t.state.ContainsAccessListAddress(addr)
+}
+
+func (t *Transition) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.ContainsAccessListSlot(addr, slot)
+}
+
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isShanghai bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -1070,25 +1189,54 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		cost += zeros * 4
 	}
 
+	// Initcode is metered per word on the shanghai fork (EIP-3860)
+	if msg.IsContractCreation() && isShanghai {
+		words := (uint64(len(payload)) + 31) / 32
+
+		if (math.MaxUint64-cost)/runtime.InitCodeWordGas < words {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += words * runtime.InitCodeWordGas
+	}
+
+	// Access list is only paid for the typed transactions (EIP-2930)
+	if msg.Type == types.AccessListTx || msg.Type == types.DynamicFeeTx {
+		cost += uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		cost += uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
+	}
+
 	return cost, nil
 }
 
 // checkAndProcessTx - first check if this message satisfies all consensus rules before
 // applying the message. The rules include these clauses:
 // 1. the nonce of the message caller is correct
-// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
+// 2. the transaction type is enabled by the active forks
+// 3. the initcode of a contract creation does not exceed the size limit
+// 4. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
 func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	// 2. check dynamic fees of the transaction
+	// 2. the transaction type is enabled
+	if err := t.checkTxType(msg); err != nil {
+		return NewTransitionApplicationError(err, false)
+	}
+
+	// 3. the initcode of a contract creation does not exceed the size limit (EIP-3860)
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > runtime.MaxInitCodeSize {
+		return NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
+	// 4. check dynamic fees of the transaction
 	if err := t.checkDynamicFees(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	// 3. caller has enough balance to cover transaction
+	// 5. caller has enough balance to cover transaction
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
@@ -66,6 +66,99 @@ func TestOverride(t *testing.T) {
 	require.Equal(t, types.Hash{0x1}, tt.state.GetState(types.Address{0x1}, types.Hash{0x1}))
 }
 
+func TestApply_ClearsAccessListBetweenTransactions(t *testing.T) {
+	t.Parallel()
+
+	// the low addresses are taken by the precompiles
+	contract := types.StringToAddress("1000")
+	cold := types.StringToAddress("2000")
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		addr1: {
+			Balance: 1000000,
+		},
+	})
+
+	tt := NewTransition(chain.ForksInTime{Homestead: true, Istanbul: true, Berlin: true}, state, newTxn(state))
+	tt.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 1000000}
+	tt.gasPool = 1000000
+
+	// PUSH20 cold BALANCE POP STOP
+	code := append(append([]byte{0x73}, cold.Bytes()...), 0x31, 0x50, 0x00)
+	tt.state.SetCode(contract, code)
+
+	gasUsed := make([]uint64, 2)
+
+	// the transactions are applied back to back, as the trace endpoints do
+	for i := range gasUsed {
+		result, err := tt.Apply(&types.Transaction{
+			From:     addr1,
+			To:       &contract,
+			Nonce:    uint64(i),
+			Gas:      100000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(0),
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+
+		gasUsed[i] = result.GasUsed
+	}
+
+	// the second transaction pays the cold access again
+	require.Equal(t, gasUsed[0], gasUsed[1])
+}
+
+func TestApply_ClearsTransientStateBetweenTransactions(t *testing.T) {
+	t.Parallel()
+
+	// the low addresses are taken by the precompiles
+	storer := types.StringToAddress("1000")
+	destructor := types.StringToAddress("2000")
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		addr1: {
+			Balance: 1000000,
+		},
+	})
+
+	config := chain.ForksInTime{Homestead: true, Istanbul: true, Berlin: true, Shanghai: true, Cancun: true}
+	tt := NewTransition(config, state, newTxn(state))
+	tt.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 1000000}
+	tt.gasPool = 1000000
+
+	// PUSH1 0 TLOAD PUSH1 0 SSTORE PUSH1 1 PUSH1 0 TSTORE STOP
+	tt.state.SetCode(storer, []byte{0x60, 0x0, 0x5c, 0x60, 0x0, 0x55, 0x60, 0x1, 0x60, 0x0, 0x5d, 0x0})
+
+	// PUSH20 addr1 SELFDESTRUCT
+	tt.state.SetCode(destructor, append(append([]byte{0x73}, addr1.Bytes()...), 0xff))
+
+	// the destructor is marked as created by a previous transaction of the block
+	tt.state.MarkCreatedInTx(destructor)
+
+	// the transactions are applied back to back, as the trace endpoints do
+	for i, to := range []types.Address{storer, storer, destructor} {
+		to := to
+
+		result, err := tt.Apply(&types.Transaction{
+			From:     addr1,
+			To:       &to,
+			Nonce:    uint64(i),
+			Gas:      100000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(0),
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+	}
+
+	// the second transaction does not load the value stored by the first one (EIP-1153)
+	require.Equal(t, types.ZeroHash, tt.state.GetState(storer, types.ZeroHash))
+
+	// the account created by another transaction is not destroyed (EIP-6780)
+	require.False(t, tt.state.HasSuicided(destructor))
+}
+
 func Test_Transition_checkDynamicFees(t *testing.T) {
 	t.Parallel()
 
@@ -110,6 +110,94 @@ func copyTrie(node Node, storage Storage, newStorage Storage, agg []byte, isStor
 	return nil
 }
 
+// CopyTrieResumable copies the trie like CopyTrie, but a node is written only after its whole
+// subtree has been copied. So a node found in the new storage is skipped along with its subtree,
+// and an interrupted copy is resumed by calling it again over the same new storage
+func CopyTrieResumable(nodeHash []byte, storage Storage, newStorage Storage, agg []byte, isStorage bool) error {
+	if _, ok := newStorage.Get(nodeHash); ok {
+		return nil
+	}
+
+	node, data, err := getCustomNode(nodeHash, storage)
+	if err != nil {
+		return err
+	}
+
+	if node == nil {
+		return fmt.Errorf("cant find node %s", hex.EncodeToString(nodeHash))
+	}
+
+	if err := copyTrieResumable(node, storage, newStorage, agg, isStorage); err != nil {
+		return err
+	}
+
+	newStorage.Put(nodeHash, data)
+
+	return nil
+}
+
+func copyTrieResumable(node Node, storage Storage, newStorage Storage, agg []byte, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *FullNode:
+		if len(n.hash) > 0 {
+			return CopyTrieResumable(n.hash, storage, newStorage, agg, isStorage)
+		}
+
+		for i := range n.children {
+			if n.children[i] == nil {
+				continue
+			}
+
+			err := copyTrieResumable(n.children[i], storage, newStorage, append(agg, uint8(i)), isStorage)
+			if err != nil {
+				return err
+			}
+		}
+
+	case *ValueNode:
+		if n.hash {
+			return CopyTrieResumable(n.buf, storage, newStorage, agg, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("cant parse account %s: %w", hex.EncodeToString(encodeCompact(agg)), err)
+		}
+
+		if account.CodeHash != nil && !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			codeHash := types.BytesToHash(account.CodeHash)
+
+			if _, ok := newStorage.GetCode(codeHash); !ok {
+				code, ok := storage.GetCode(codeHash)
+				if !ok {
+					return fmt.Errorf("cant find code %s", hex.EncodeToString(account.CodeHash))
+				}
+
+				newStorage.SetCode(codeHash, code)
+			}
+		}
+
+		if account.Root != types.EmptyRootHash {
+			return CopyTrieResumable(account.Root[:], storage, newStorage, nil, true)
+		}
+
+	case *ShortNode:
+		if len(n.hash) > 0 {
+			return CopyTrieResumable(n.hash, storage, newStorage, agg, isStorage)
+		}
+
+		return copyTrieResumable(n.child, storage, newStorage, append(agg, n.key...), isStorage)
+	}
+
+	return nil
+}
+
 func HashChecker(stateRoot []byte, storage Storage) (types.Hash, error) {
 	node, _, err := GetNode(stateRoot, storage)
 	if err != nil {
@@ -78,3 +78,18 @@ func decodeCompact(compact []byte) []byte {
 
 	return base
 }
+
+// hexNibblesToBytes packs a hex sequence (of nibbles)
+// into bytes, the terminator flag is removed.
+func hexNibblesToBytes(hex []byte) []byte {
+	if hasTerminator(hex) {
+		hex = hex[:len(hex)-1]
+	}
+
+	result := make([]byte, len(hex)/2)
+	for i := range result {
+		result[i] = hex[2*i]<<4 | hex[2*i+1]
+	}
+
+	return result
+}
@@ -0,0 +1,838 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// DefaultFlatStateRetain is the default number of the latest states kept as diff layers
+	DefaultFlatStateRetain = 64
+
+	// flatBatchSize is the number of the entries written to the disk layer at once
+	flatBatchSize = 10000
+)
+
+var (
+	// flatAccountPrefix is the prefix of the accounts, followed by the account hash
+	flatAccountPrefix = []byte("a")
+
+	// flatStoragePrefix is the prefix of the storage slots, followed by the account hash and the slot hash
+	flatStoragePrefix = []byte("s")
+
+	// flatRootKey is the key of the state root of the disk layer
+	flatRootKey = []byte("flat-root")
+
+	// flatGeneratorKey is the key of the last account hash generated, it is deleted once the disk layer is verified
+	flatGeneratorKey = []byte("flat-generator")
+
+	errFlatGeneratorAborted = errors.New("flat state generation aborted")
+)
+
+func flatAccountKey(hash []byte) []byte {
+	return concat(flatAccountPrefix, hash)
+}
+
+func flatStorageKey(accountHash, slotHash []byte) []byte {
+	return concat(concat(flatStoragePrefix, accountHash), slotHash)
+}
+
+// flatDiff is the flat state changed by a commit
+type flatDiff struct {
+	accounts  map[types.Hash][]byte                // nil for the deleted accounts
+	storage   map[types.Hash]map[types.Hash][]byte // nil for the deleted slots
+	destructs map[types.Hash]struct{}              // accounts whose previous storage is wiped
+}
+
+func newFlatDiff() *flatDiff {
+	return &flatDiff{
+		accounts:  make(map[types.Hash][]byte),
+		storage:   make(map[types.Hash]map[types.Hash][]byte),
+		destructs: make(map[types.Hash]struct{}),
+	}
+}
+
+// The setters of the diff are no-ops on a nil diff, so the commits don't track changes
+// if the flat state isn't used
+
+func (d *flatDiff) setAccount(hash, data []byte) {
+	if d == nil {
+		return
+	}
+
+	d.accounts[types.BytesToHash(hash)] = data
+}
+
+func (d *flatDiff) setSlot(accountHash, slotHash, value []byte) {
+	if d == nil {
+		return
+	}
+
+	slots, ok := d.storage[types.BytesToHash(accountHash)]
+	if !ok {
+		slots = make(map[types.Hash][]byte)
+		d.storage[types.BytesToHash(accountHash)] = slots
+	}
+
+	slots[types.BytesToHash(slotHash)] = value
+}
+
+func (d *flatDiff) destruct(accountHash []byte) {
+	if d == nil {
+		return
+	}
+
+	d.destructs[types.BytesToHash(accountHash)] = struct{}{}
+}
+
+// diffLayer is the flat state changed by a commit on top of its parent
+type diffLayer struct {
+	*flatDiff
+
+	root   types.Hash
+	parent *diffLayer // nil if the layer is on top of the disk layer
+}
+
+// FlatState keeps the accounts and the storage slots keyed by their hashes,
+// so they are read without walking the tries.
+// Every commit adds a diff layer in memory on top of the layer of its parent state.
+// The diff layers below the last retained ones of the head are merged into the disk layer.
+// The disk layer of an existing database is generated from the trie in the background,
+// the accounts which haven't been generated yet are read from the trie.
+// Once all the accounts are generated, the disk layer is verified against its state root
+type FlatState struct {
+	logger  hclog.Logger
+	db      *leveldb.DB
+	storage Storage
+	retain  uint64
+
+	lock   sync.RWMutex
+	layers map[types.Hash]*diffLayer
+
+	// diskRoot is the state root of the disk layer
+	diskRoot types.Hash
+
+	// marker is the last account hash generated, nil once all the accounts are generated
+	marker []byte
+
+	// verified is set once the generated disk layer is verified
+	verified bool
+
+	// disabled is set if the disk layer failed the verification
+	disabled bool
+
+	// capLock serializes the merges into the disk layer with the control of the generator
+	capLock  sync.Mutex
+	head     types.Hash
+	genAbort chan struct{}
+	genDone  chan struct{}
+	closed   bool
+}
+
+// NewFlatState creates the flat state of the trie storage at the root of the head state.
+// The disk layer is (re)generated if it doesn't match the root
+func NewFlatState(
+	db *leveldb.DB,
+	storage Storage,
+	root types.Hash,
+	retain uint64,
+	logger hclog.Logger,
+) (*FlatState, error) {
+	f := &FlatState{
+		logger:   logger.Named("flat-state"),
+		db:       db,
+		storage:  storage,
+		retain:   retain,
+		layers:   make(map[types.Hash]*diffLayer),
+		diskRoot: root,
+		head:     root,
+		verified: true,
+	}
+
+	diskRoot, err := db.Get(flatRootKey, nil)
+	if err != nil || types.BytesToHash(diskRoot) != root {
+		f.logger.Info("Generating the flat state", "root", root)
+
+		if err := f.wipe(root); err != nil {
+			return nil, fmt.Errorf("failed to reset the flat state: %w", err)
+		}
+
+		f.marker = []byte{}
+		f.verified = false
+	} else if marker, err := db.Get(flatGeneratorKey, nil); err == nil {
+		f.logger.Info("Resuming the generation of the flat state", "root", root, "marker", hex.EncodeToHex(marker))
+
+		f.marker = append([]byte{}, marker...)
+		f.verified = false
+	}
+
+	f.startGenerator()
+
+	return f, nil
+}
+
+// account returns the encoded account from the flat state at the root, nil if the account doesn't exist.
+// Returns false if the flat state at the root doesn't know the account
+func (f *FlatState) account(root types.Hash, hash []byte) ([]byte, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	layer, ok := f.layers[root]
+	if !ok && root != f.diskRoot {
+		return nil, false
+	}
+
+	for ; layer != nil; layer = layer.parent {
+		if data, ok := layer.accounts[types.BytesToHash(hash)]; ok {
+			return data, true
+		}
+	}
+
+	if !f.generated(hash) {
+		return nil, false
+	}
+
+	return f.diskGet(flatAccountKey(hash))
+}
+
+// storageSlot returns the encoded storage slot from the flat state at the root,
+// nil if the slot is empty. Returns false if the flat state at the root doesn't know the slot
+func (f *FlatState) storageSlot(root types.Hash, accountHash, slotHash []byte) ([]byte, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	layer, ok := f.layers[root]
+	if !ok && root != f.diskRoot {
+		return nil, false
+	}
+
+	for ; layer != nil; layer = layer.parent {
+		if slots, ok := layer.storage[types.BytesToHash(accountHash)]; ok {
+			if value, ok := slots[types.BytesToHash(slotHash)]; ok {
+				return value, true
+			}
+		}
+
+		if _, ok := layer.destructs[types.BytesToHash(accountHash)]; ok {
+			return nil, true
+		}
+	}
+
+	if !f.generated(accountHash) {
+		return nil, false
+	}
+
+	return f.diskGet(flatStorageKey(accountHash, slotHash))
+}
+
+// generated checks if the account has been generated in the disk layer
+func (f *FlatState) generated(accountHash []byte) bool {
+	return !f.disabled && (f.marker == nil || bytes.Compare(accountHash, f.marker) <= 0)
+}
+
+func (f *FlatState) diskGet(key []byte) ([]byte, bool) {
+	data, err := f.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, true
+	}
+
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// update adds the diff layer of the commit on top of the layer of the parent state
+func (f *FlatState) update(parentRoot, root types.Hash, diff *flatDiff) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if root == parentRoot || root == f.diskRoot {
+		// the state hasn't changed
+		return
+	}
+
+	if _, ok := f.layers[root]; ok {
+		return
+	}
+
+	parent, ok := f.layers[parentRoot]
+	if !ok && parentRoot != f.diskRoot {
+		// the parent state isn't tracked, e.g. a historical state
+		return
+	}
+
+	f.layers[root] = &diffLayer{
+		flatDiff: diff,
+		root:     root,
+		parent:   parent,
+	}
+}
+
+// Cap merges the diff layers of the head state below the retained ones into the disk layer,
+// and drops the diff layers which don't descend from the new disk layer
+func (f *FlatState) Cap(head types.Hash) {
+	f.capLock.Lock()
+	defer f.capLock.Unlock()
+
+	if f.closed {
+		return
+	}
+
+	f.head = head
+
+	f.lock.RLock()
+
+	depth := uint64(0)
+	for layer := f.layers[head]; layer != nil; layer = layer.parent {
+		depth++
+	}
+
+	generating := f.marker != nil
+
+	f.lock.RUnlock()
+
+	if depth <= f.retain {
+		return
+	}
+
+	if generating {
+		// the generator continues from the new disk layer,
+		// the verification reads a snapshot of the database, so it isn't stopped
+		f.stopGenerator()
+		defer f.startGenerator()
+	}
+
+	if err := f.flatten(head, f.retain); err != nil {
+		f.logger.Error("failed to merge the diff layers into the disk layer", "err", err)
+	}
+}
+
+// Close merges the diff layers of the head state into the disk layer and closes the database
+func (f *FlatState) Close() error {
+	f.capLock.Lock()
+	defer f.capLock.Unlock()
+
+	f.closed = true
+
+	f.stopGenerator()
+
+	if err := f.flatten(f.head, 0); err != nil {
+		f.logger.Error("failed to merge the diff layers into the disk layer", "err", err)
+	}
+
+	return f.db.Close()
+}
+
+// flatten merges the diff layers of the root below the retained ones into the disk layer, oldest first
+func (f *FlatState) flatten(root types.Hash, retain uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	chain := make([]*diffLayer, 0)
+	for layer := f.layers[root]; layer != nil; layer = layer.parent {
+		chain = append(chain, layer)
+	}
+
+	for i := len(chain) - 1; i >= 0 && uint64(i) >= retain; i-- {
+		layer := chain[i]
+
+		if err := f.writeLayer(layer); err != nil {
+			return err
+		}
+
+		f.diskRoot = layer.root
+		delete(f.layers, layer.root)
+
+		for hash, other := range f.layers {
+			if other.parent == nil {
+				// a sibling of the merged layer
+				delete(f.layers, hash)
+			} else if other.parent == layer {
+				other.parent = nil
+			}
+		}
+	}
+
+	// the descendants of the dropped layers
+	for hash, layer := range f.layers {
+		for parent := layer.parent; parent != nil; parent = parent.parent {
+			if f.layers[parent.root] != parent {
+				delete(f.layers, hash)
+
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeLayer writes the diff layer to the disk layer.
+// The accounts which haven't been generated yet are left to the generator
+func (f *FlatState) writeLayer(layer *diffLayer) error {
+	batch := new(leveldb.Batch)
+
+	for hash := range layer.destructs {
+		if !f.generated(hash.Bytes()) {
+			continue
+		}
+
+		if err := f.deleteStorage(batch, hash.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	for hash, data := range layer.accounts {
+		if !f.generated(hash.Bytes()) {
+			continue
+		}
+
+		if data == nil {
+			batch.Delete(flatAccountKey(hash.Bytes()))
+		} else {
+			batch.Put(flatAccountKey(hash.Bytes()), data)
+		}
+	}
+
+	for accountHash, slots := range layer.storage {
+		if !f.generated(accountHash.Bytes()) {
+			continue
+		}
+
+		for slotHash, value := range slots {
+			if value == nil {
+				batch.Delete(flatStorageKey(accountHash.Bytes(), slotHash.Bytes()))
+			} else {
+				batch.Put(flatStorageKey(accountHash.Bytes(), slotHash.Bytes()), value)
+			}
+		}
+	}
+
+	batch.Put(flatRootKey, layer.root.Bytes())
+
+	return f.db.Write(batch, nil)
+}
+
+// deleteStorage deletes the storage slots of the account in the disk layer
+func (f *FlatState) deleteStorage(batch *leveldb.Batch, accountHash []byte) error {
+	iter := f.db.NewIterator(util.BytesPrefix(concat(flatStoragePrefix, accountHash)), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+
+	return iter.Error()
+}
+
+// wipe deletes the disk layer and starts the generation of the root
+func (f *FlatState) wipe(root types.Hash) error {
+	iter := f.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
+
+	for iter.Next() {
+		batch.Delete(iter.Key())
+
+		if batch.Len() >= flatBatchSize {
+			if err := f.db.Write(batch, nil); err != nil {
+				return err
+			}
+
+			batch.Reset()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	batch.Put(flatRootKey, root.Bytes())
+	batch.Put(flatGeneratorKey, []byte{})
+
+	return f.db.Write(batch, nil)
+}
+
+// startGenerator generates the rest of the disk layer and verifies it in the background, if it isn't done
+func (f *FlatState) startGenerator() {
+	f.lock.RLock()
+	root, marker, done := f.diskRoot, f.marker, f.verified || f.disabled
+	f.lock.RUnlock()
+
+	if done {
+		return
+	}
+
+	f.genAbort = make(chan struct{})
+	f.genDone = make(chan struct{})
+
+	go f.generate(root, marker, f.genAbort, f.genDone)
+}
+
+// stopGenerator stops the generator, its progress is persisted
+func (f *FlatState) stopGenerator() {
+	if f.genAbort == nil {
+		return
+	}
+
+	close(f.genAbort)
+	<-f.genDone
+
+	f.genAbort, f.genDone = nil, nil
+}
+
+// generate writes the accounts of the root after the marker and their storage to the disk layer,
+// and verifies the disk layer once all the accounts are generated
+func (f *FlatState) generate(root types.Hash, marker []byte, abort, done chan struct{}) {
+	defer close(done)
+
+	if marker != nil {
+		g := &flatGenerator{
+			f:      f,
+			abort:  abort,
+			batch:  new(leveldb.Batch),
+			marker: marker,
+		}
+
+		err := g.generateAccounts(root)
+		if errors.Is(err, errFlatGeneratorAborted) {
+			return
+		}
+
+		if err != nil {
+			f.fail(err)
+
+			return
+		}
+
+		f.lock.Lock()
+		f.marker = nil
+		f.lock.Unlock()
+
+		f.logger.Info("Generated the flat state", "root", root)
+	}
+
+	// the diff layers merged from now on don't change the verified snapshot
+	f.lock.RLock()
+	root = f.diskRoot
+	snapshot, err := f.db.GetSnapshot()
+	f.lock.RUnlock()
+
+	if err != nil {
+		f.logger.Error("failed to verify the flat state", "err", err)
+
+		return
+	}
+
+	defer snapshot.Release()
+
+	err = f.verify(snapshot, root, abort)
+	if errors.Is(err, errFlatGeneratorAborted) {
+		return
+	}
+
+	if err != nil {
+		f.fail(err)
+
+		return
+	}
+
+	if err := f.db.Delete(flatGeneratorKey, nil); err != nil {
+		f.logger.Error("failed to complete the generation of the flat state", "err", err)
+
+		return
+	}
+
+	f.lock.Lock()
+	f.verified = true
+	f.lock.Unlock()
+
+	f.logger.Info("Verified the flat state", "root", root)
+}
+
+// fail disables the flat state, it is generated again on the next start
+func (f *FlatState) fail(err error) {
+	f.logger.Error("failed to generate the flat state, it is disabled", "err", err)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.disabled = true
+
+	if err := f.wipe(f.diskRoot); err != nil {
+		f.logger.Error("failed to reset the flat state", "err", err)
+	}
+}
+
+// verify hashes the tries from the snapshot of the disk layer and checks the state root.
+// The snapshot is iterated in the order of the keys, so the tries are hashed as they are streamed
+func (f *FlatState) verify(snapshot *leveldb.Snapshot, root types.Hash, abort chan struct{}) error {
+	accounts := NewStackTrie()
+
+	iter := snapshot.NewIterator(util.BytesPrefix(flatAccountPrefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		select {
+		case <-abort:
+			return errFlatGeneratorAborted
+		default:
+		}
+
+		hash := append([]byte{}, iter.Key()[len(flatAccountPrefix):]...)
+		data := append([]byte{}, iter.Value()...)
+
+		var account state.Account
+		if err := account.UnmarshalRlp(data); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", hex.EncodeToHex(hash), err)
+		}
+
+		storageRoot, err := storageRoot(snapshot, hash)
+		if err != nil {
+			return err
+		}
+
+		if storageRoot != account.Root {
+			return fmt.Errorf("storage root of account %s doesn't match", hex.EncodeToHex(hash))
+		}
+
+		if err := accounts.Insert(hash, data); err != nil {
+			return err
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	stateRoot, err := accounts.Hash()
+	if err != nil {
+		return err
+	}
+
+	if types.BytesToHash(stateRoot) != root {
+		return fmt.Errorf("state root %s doesn't match", types.BytesToHash(stateRoot))
+	}
+
+	return nil
+}
+
+// storageRoot computes the storage root of the account from the snapshot of the disk layer
+func storageRoot(snapshot *leveldb.Snapshot, accountHash []byte) (types.Hash, error) {
+	slots := NewStackTrie()
+
+	iter := snapshot.NewIterator(util.BytesPrefix(concat(flatStoragePrefix, accountHash)), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		slotHash := iter.Key()[len(flatStoragePrefix)+len(accountHash):]
+
+		if err := slots.Insert(slotHash, iter.Value()); err != nil {
+			return types.Hash{}, err
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return types.Hash{}, err
+	}
+
+	root, err := slots.Hash()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.BytesToHash(root), nil
+}
+
+// flatGenerator writes the accounts and their storage from the trie to the disk layer
+type flatGenerator struct {
+	f     *FlatState
+	abort chan struct{}
+	batch *leveldb.Batch
+
+	// marker is the last account hash generated
+	marker []byte
+}
+
+func (g *flatGenerator) generateAccounts(root types.Hash) (err error) {
+	defer func() {
+		// the progress is persisted, so the generation is resumed after it
+		if writeErr := g.write(); err == nil {
+			err = writeErr
+		}
+	}()
+
+	node, err := g.getRoot(root)
+	if err != nil {
+		return err
+	}
+
+	var after []byte
+	if len(g.marker) != 0 {
+		after = bytesToHexNibbles(g.marker)
+	}
+
+	return walkLeaves(g.f.storage, node, nil, after, func(key, value []byte) error {
+		select {
+		case <-g.abort:
+			return errFlatGeneratorAborted
+		default:
+		}
+
+		// the storage written by an interrupted generation of the account
+		if err := g.f.deleteStorage(g.batch, key); err != nil {
+			return err
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", hex.EncodeToHex(key), err)
+		}
+
+		if err := g.generateStorage(key, account.Root); err != nil {
+			return err
+		}
+
+		g.batch.Put(flatAccountKey(key), value)
+		g.marker = key
+
+		if g.batch.Len() >= flatBatchSize {
+			return g.write()
+		}
+
+		return nil
+	})
+}
+
+func (g *flatGenerator) generateStorage(accountHash []byte, root types.Hash) error {
+	node, err := g.getRoot(root)
+	if err != nil {
+		return err
+	}
+
+	return walkLeaves(g.f.storage, node, nil, nil, func(key, value []byte) error {
+		g.batch.Put(flatStorageKey(accountHash, key), value)
+
+		if g.batch.Len() < flatBatchSize {
+			return nil
+		}
+
+		select {
+		case <-g.abort:
+			return errFlatGeneratorAborted
+		default:
+		}
+
+		return g.write()
+	})
+}
+
+func (g *flatGenerator) getRoot(root types.Hash) (Node, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), g.f.storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("state not found at hash %s", root)
+	}
+
+	return node, nil
+}
+
+// write writes the batch together with the marker, and publishes the marker
+func (g *flatGenerator) write() error {
+	g.batch.Put(flatGeneratorKey, g.marker)
+
+	if err := g.f.db.Write(g.batch, nil); err != nil {
+		return err
+	}
+
+	g.batch.Reset()
+
+	g.f.lock.Lock()
+	g.f.marker = g.marker
+	g.f.lock.Unlock()
+
+	return nil
+}
+
+// walkLeaves calls the callback with the key and the value of every leaf of the trie
+// after the given key (in nibbles), in key order
+func walkLeaves(storage Storage, node Node, path, after []byte, fn func(key, value []byte) error) error {
+	if after != nil && skipPath(path, after) {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %s not found", hex.EncodeToHex(n.buf))
+			}
+
+			return walkLeaves(storage, child, path, after, fn)
+		}
+
+		return fn(hexNibblesToBytes(path), n.buf)
+
+	case *ShortNode:
+		return walkLeaves(storage, n.child, concat(path, n.key), after, fn)
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkLeaves(storage, child, concat(path, []byte{byte(i)}), after, fn); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return walkLeaves(storage, n.value, concat(path, []byte{16}), after, fn)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// skipPath checks if all the keys under the path are at or before the given key
+func skipPath(path, after []byte) bool {
+	n := len(path)
+	if len(after) < n {
+		n = len(after)
+	}
+
+	cmp := bytes.Compare(path[:n], after[:n])
+
+	return cmp < 0 || cmp == 0 && len(path) >= len(after)
+}
@@ -0,0 +1,273 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+var (
+	flatAddrs = []types.Address{
+		types.StringToAddress("1"),
+		types.StringToAddress("2"),
+		types.StringToAddress("3"),
+	}
+	flatSlots = []types.Hash{
+		types.StringToHash("1"),
+		types.StringToHash("2"),
+		types.StringToHash("3"),
+	}
+)
+
+func openFlatTestDB(t *testing.T, stor storage.Storage) *leveldb.DB {
+	t.Helper()
+
+	db, err := leveldb.Open(stor, nil)
+	require.NoError(t, err)
+
+	return db
+}
+
+// waitFlatGenerated waits for the generator of the flat state to finish
+func waitFlatGenerated(f *FlatState) {
+	if f.genDone != nil {
+		<-f.genDone
+	}
+}
+
+// commitFlatBlock commits the changes of the callback on top of the snapshot
+func commitFlatBlock(snap state.Snapshot, change func(txn *state.Txn)) (state.Snapshot, types.Hash) {
+	txn := state.NewTxn(snap)
+	change(txn)
+
+	snap, root := snap.Commit(txn.Commit(true))
+
+	return snap, types.BytesToHash(root)
+}
+
+// assertFlatState checks the flat state at the root knows the accounts and the slots,
+// and they match the trie
+func assertFlatState(t *testing.T, f *FlatState, trieStorage Storage, root types.Hash) {
+	t.Helper()
+
+	withFlat := NewState(trieStorage)
+	withFlat.SetFlatState(f)
+
+	flatSnap, err := withFlat.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	trieSnap, err := NewState(trieStorage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range flatAddrs {
+		_, ok := f.account(root, hashit(addr.Bytes()))
+		assert.True(t, ok)
+
+		expected, err := trieSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := flatSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, account)
+
+		if expected == nil {
+			continue
+		}
+
+		for _, slot := range flatSlots {
+			_, ok := f.storageSlot(root, hashit(addr.Bytes()), hashit(slot.Bytes()))
+			assert.True(t, ok)
+
+			assert.Equal(
+				t,
+				trieSnap.GetStorage(addr, expected.Root, slot),
+				flatSnap.GetStorage(addr, expected.Root, slot),
+			)
+		}
+	}
+}
+
+func TestFlatState_Layers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stor        = storage.NewMemStorage()
+		db          = openFlatTestDB(t, stor)
+		trieStorage = NewMemoryStorage()
+		st          = NewState(trieStorage)
+		roots       = make([]types.Hash, 0)
+	)
+
+	snap, root := commitFlatBlock(st.NewSnapshot(), func(txn *state.Txn) {
+		txn.SetState(flatAddrs[0], flatSlots[0], types.StringToHash("10"))
+		txn.SetState(flatAddrs[0], flatSlots[1], types.StringToHash("11"))
+		txn.SetNonce(flatAddrs[1], 1)
+	})
+	roots = append(roots, root)
+
+	f, err := NewFlatState(db, trieStorage, root, 2, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	waitFlatGenerated(f)
+	st.SetFlatState(f)
+
+	blocks := []func(txn *state.Txn){
+		func(txn *state.Txn) {
+			txn.SetState(flatAddrs[0], flatSlots[0], types.StringToHash("20"))
+			txn.SetState(flatAddrs[0], flatSlots[1], types.ZeroHash)
+			txn.SetNonce(flatAddrs[2], 2)
+		},
+		func(txn *state.Txn) {
+			// the storage of the recreated account is wiped
+			txn.CreateAccount(flatAddrs[0])
+			txn.SetNonce(flatAddrs[0], 3)
+			txn.SetState(flatAddrs[0], flatSlots[2], types.StringToHash("32"))
+		},
+		func(txn *state.Txn) {
+			txn.Suicide(flatAddrs[1])
+		},
+		func(txn *state.Txn) {
+			txn.SetState(flatAddrs[2], flatSlots[0], types.StringToHash("50"))
+		},
+	}
+
+	var rejected types.Hash
+
+	for i, block := range blocks {
+		if i == 1 {
+			// a proposal which doesn't end up in a block
+			_, rejected = commitFlatBlock(snap, func(txn *state.Txn) {
+				txn.SetNonce(flatAddrs[1], 100)
+			})
+			assertFlatState(t, f, trieStorage, rejected)
+		}
+
+		snap, root = commitFlatBlock(snap, block)
+		roots = append(roots, root)
+
+		f.Cap(root)
+
+		assertFlatState(t, f, trieStorage, root)
+	}
+
+	// the older layers are merged into the disk layer
+	assert.Equal(t, roots[2], f.diskRoot)
+	assert.Len(t, f.layers, 2)
+
+	_, ok := f.account(rejected, hashit(flatAddrs[1].Bytes()))
+	assert.False(t, ok)
+
+	_, ok = f.account(roots[1], hashit(flatAddrs[1].Bytes()))
+	assert.False(t, ok)
+
+	for _, root := range roots[2:] {
+		assertFlatState(t, f, trieStorage, root)
+	}
+
+	// the layers of the head are merged into the disk layer on close
+	require.NoError(t, f.Close())
+
+	db = openFlatTestDB(t, stor)
+
+	f, err = NewFlatState(db, trieStorage, root, 2, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	assert.Nil(t, f.marker)
+	assert.True(t, f.verified)
+	assertFlatState(t, f, trieStorage, root)
+
+	snapshot, err := db.GetSnapshot()
+	require.NoError(t, err)
+
+	assert.NoError(t, f.verify(snapshot, root, make(chan struct{})))
+	snapshot.Release()
+
+	require.NoError(t, f.Close())
+}
+
+func TestFlatState_Generate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stor        = storage.NewMemStorage()
+		db          = openFlatTestDB(t, stor)
+		trieStorage = NewMemoryStorage()
+		st          = NewState(trieStorage)
+	)
+
+	_, root := commitFlatBlock(st.NewSnapshot(), func(txn *state.Txn) {
+		for i := 0; i < 100; i++ {
+			addr := types.StringToAddress(string(rune('a' + i)))
+			txn.SetNonce(addr, uint64(i))
+			txn.SetState(addr, flatSlots[0], types.BytesToHash([]byte{byte(i + 1)}))
+		}
+
+		for _, addr := range flatAddrs {
+			txn.SetBalance(addr, big.NewInt(1))
+			txn.SetState(addr, flatSlots[1], types.StringToHash("2"))
+		}
+	})
+
+	f, err := NewFlatState(db, trieStorage, root, 2, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	waitFlatGenerated(f)
+
+	assert.Nil(t, f.marker)
+	assert.True(t, f.verified)
+	assert.False(t, f.disabled)
+	assertFlatState(t, f, trieStorage, root)
+
+	// interrupted in the middle of the accounts
+	marker := hashit(flatAddrs[1].Bytes())
+
+	iter := db.NewIterator(nil, nil)
+	for iter.Next() {
+		if string(iter.Key()[:1]) == string(flatAccountPrefix) && string(iter.Key()[1:]) > string(marker) {
+			require.NoError(t, db.Delete(iter.Key(), nil))
+		}
+	}
+	iter.Release()
+
+	require.NoError(t, db.Put(flatGeneratorKey, marker, nil))
+	require.NoError(t, f.Close())
+
+	db = openFlatTestDB(t, stor)
+
+	f, err = NewFlatState(db, trieStorage, root, 2, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	waitFlatGenerated(f)
+
+	assert.Nil(t, f.marker)
+	assert.True(t, f.verified)
+	assert.False(t, f.disabled)
+	assertFlatState(t, f, trieStorage, root)
+
+	// an account which isn't in the trie fails the verification
+	require.NoError(t, db.Put(flatAccountKey(types.StringToHash("ff").Bytes()), []byte{0xc0}, nil))
+	require.NoError(t, db.Put(flatGeneratorKey, []byte{}, nil))
+	require.NoError(t, f.Close())
+
+	db = openFlatTestDB(t, stor)
+
+	f, err = NewFlatState(db, trieStorage, root, 2, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	waitFlatGenerated(f)
+
+	assert.True(t, f.disabled)
+
+	_, ok := f.account(root, hashit(flatAddrs[0].Bytes()))
+	assert.False(t, ok)
+
+	require.NoError(t, f.Close())
+}
@@ -0,0 +1,138 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// Prove returns the merkle proof of the key, which is the list of the RLP encoded
+// nodes on the path from the root towards the key. The nodes are read from the storage,
+// hence the trie has to be committed. The proof of a missing key proves its absence
+func (t *Trie) Prove(key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if t.root == nil {
+		return proof, nil
+	}
+
+	next, err := t.Txn(storage).Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	path := bytesToHexNibbles(key)
+
+	for next != nil {
+		data, ok := storage.Get(next)
+		if !ok {
+			return nil, fmt.Errorf("trie node not found at hash %s", hex.EncodeToHex(next))
+		}
+
+		proof = append(proof, append([]byte{}, data...))
+
+		if next, path, _, err = walkProofNode(data, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the merkle proof of the key against the root and returns the
+// value stored under the key, or nil if the proof proves the key is missing
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(hashit(node))] = node
+	}
+
+	var (
+		next  = root.Bytes()
+		path  = bytesToHexNibbles(key)
+		value []byte
+		err   error
+	)
+
+	for next != nil {
+		data, ok := nodes[types.BytesToHash(next)]
+		if !ok {
+			return nil, fmt.Errorf("proof node not found at hash %s", hex.EncodeToHex(next))
+		}
+
+		if next, path, value, err = walkProofNode(data, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// walkProofNode follows the path through the encoded node and its embedded children.
+// It returns the hash of the next stored node on the path along with the remaining path,
+// or the value found once the path ends within the node
+func walkProofNode(data []byte, path []byte) ([]byte, []byte, []byte, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for {
+		if v.Type() == fastrlp.TypeBytes {
+			if len(v.Raw()) == 0 {
+				// empty child, the key is missing
+				return nil, nil, nil, nil
+			}
+
+			// reference to the next stored node
+			return append([]byte{}, v.Raw()...), path, nil, nil
+		}
+
+		switch v.Elems() {
+		case 2:
+			key := v.Get(0)
+			if key.Type() != fastrlp.TypeBytes {
+				return nil, nil, nil, fmt.Errorf("short key expected to be bytes")
+			}
+
+			nibbles := decodeCompact(key.Raw())
+			if len(path) < len(nibbles) || !bytes.Equal(path[:len(nibbles)], nibbles) {
+				// the path diverges, the key is missing
+				return nil, nil, nil, nil
+			}
+
+			if hasTerminator(nibbles) {
+				return nil, nil, append([]byte{}, v.Get(1).Raw()...), nil
+			}
+
+			path = path[len(nibbles):]
+			v = v.Get(1)
+
+		case 17:
+			if len(path) == 0 {
+				return nil, nil, nil, fmt.Errorf("proof path is too short")
+			}
+
+			if path[0] == 16 {
+				value := v.Get(16).Raw()
+				if len(value) == 0 {
+					return nil, nil, nil, nil
+				}
+
+				return nil, nil, append([]byte{}, value...), nil
+			}
+
+			v = v.Get(int(path[0]))
+			path = path[1:]
+
+		default:
+			return nil, nil, nil, fmt.Errorf("node has incorrect number of leafs")
+		}
+	}
+}
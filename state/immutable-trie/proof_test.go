@@ -0,0 +1,160 @@
+package itrie
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrie_Prove(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{1, 2, 16, 500} {
+		n := n
+
+		t.Run(fmt.Sprintf("%d keys", n), func(t *testing.T) {
+			t.Parallel()
+
+			storage := NewMemoryStorage()
+			txn := NewTrie().Txn(storage)
+			txn.batch = storage.Batch()
+
+			key := func(i int) []byte {
+				return hashit([]byte(fmt.Sprint(i)))
+			}
+
+			for i := 0; i < n; i++ {
+				// short values end up embedded in their parents
+				txn.Insert(key(i), []byte{byte(i%255 + 1)})
+			}
+
+			rootBytes, err := txn.Hash()
+			require.NoError(t, err)
+
+			root := types.BytesToHash(rootBytes)
+
+			// the trie loaded from storage has to produce the same proofs
+			rootNode, ok, err := GetNode(rootBytes, storage)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			for _, trie := range []*Trie{txn.Commit(), NewTrieWithRoot(rootNode)} {
+				for i := 0; i < n; i++ {
+					proof, err := trie.Prove(key(i), storage)
+					require.NoError(t, err)
+
+					value, err := VerifyProof(root, key(i), proof)
+					require.NoError(t, err)
+					assert.Equal(t, []byte{byte(i%255 + 1)}, value)
+				}
+
+				// proof of absence
+				proof, err := trie.Prove(key(n), storage)
+				require.NoError(t, err)
+				assert.NotEmpty(t, proof)
+
+				value, err := VerifyProof(root, key(n), proof)
+				require.NoError(t, err)
+				assert.Nil(t, value)
+			}
+		})
+	}
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage.Batch()
+
+	for i := 0; i < 10; i++ {
+		txn.Insert(hashit([]byte{byte(i)}), []byte("some value long enough to be hashed"))
+	}
+
+	rootBytes, err := txn.Hash()
+	require.NoError(t, err)
+
+	key := hashit([]byte{1})
+
+	proof, err := txn.Commit().Prove(key, storage)
+	require.NoError(t, err)
+	require.Greater(t, len(proof), 1)
+
+	// missing node
+	_, err = VerifyProof(types.BytesToHash(rootBytes), key, proof[:len(proof)-1])
+	assert.Error(t, err)
+
+	// wrong root
+	_, err = VerifyProof(types.StringToHash("1"), key, proof)
+	assert.Error(t, err)
+}
+
+func TestSnapshot_GetProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = types.StringToAddress("1")
+		missing = types.StringToAddress("2")
+		slot    = types.StringToHash("3")
+		empty   = types.StringToHash("4")
+	)
+
+	st := NewState(NewMemoryStorage())
+
+	snap, rootBytes := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr,
+			Balance:  big.NewInt(100),
+			Nonce:    1,
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: slot.Bytes(), Val: types.StringToHash("5").Bytes()},
+			},
+		},
+	})
+	root := types.BytesToHash(rootBytes)
+
+	res, err := snap.GetProof(addr, []types.Hash{slot, empty})
+	require.NoError(t, err)
+
+	require.NotNil(t, res.Account)
+	assert.Equal(t, uint64(1), res.Account.Nonce)
+	assert.Equal(t, big.NewInt(100), res.Account.Balance)
+
+	account, err := VerifyProof(root, hashit(addr.Bytes()), res.Proof)
+	require.NoError(t, err)
+	assert.NotNil(t, account)
+
+	require.Len(t, res.StorageProof, 2)
+	assert.Equal(t, slot, res.StorageProof[0].Key)
+	assert.Equal(t, types.StringToHash("5"), res.StorageProof[0].Value)
+	assert.Equal(t, types.Hash{}, res.StorageProof[1].Value)
+
+	value, err := VerifyProof(res.Account.Root, hashit(slot.Bytes()), res.StorageProof[0].Proof)
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+
+	value, err = VerifyProof(res.Account.Root, hashit(empty.Bytes()), res.StorageProof[1].Proof)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// missing accounts are proven absent with empty storage proofs
+	res, err = snap.GetProof(missing, []types.Hash{slot})
+	require.NoError(t, err)
+
+	assert.Nil(t, res.Account)
+
+	account, err = VerifyProof(root, hashit(missing.Bytes()), res.Proof)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	require.Len(t, res.StorageProof, 1)
+	assert.Empty(t, res.StorageProof[0].Proof)
+}
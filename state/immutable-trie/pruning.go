@@ -0,0 +1,329 @@
+package itrie
+
+import (
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultPruningFlushInterval is the default number of blocks between the flushes of the state
+	DefaultPruningFlushInterval = 1024
+
+	// DefaultPruningRetain is the default number of the latest state roots kept queryable
+	DefaultPruningRetain = 128
+)
+
+// PruningConfig is the configuration of the state pruning
+type PruningConfig struct {
+	// FlushInterval is the number of blocks between the flushes of the state to the disk
+	FlushInterval uint64
+
+	// Retain is the number of the latest state roots kept queryable
+	Retain uint64
+}
+
+// dirtyNode is a trie node which hasn't been flushed to the disk yet
+type dirtyNode struct {
+	blob     []byte
+	children []types.Hash // referenced nodes, including the storage roots of the accounts
+	parents  uint64       // number of the references from the other nodes and the roots
+}
+
+// PruningStorage is a trie storage which keeps the written nodes in memory,
+// and flushes only the state of every FlushInterval block and the retained states on close.
+// The nodes are reference counted, so the nodes which are no longer referenced by
+// the last Retain roots are garbage collected without ever reaching the disk
+type PruningStorage struct {
+	Storage
+
+	logger hclog.Logger
+	config PruningConfig
+
+	lock    sync.RWMutex
+	dirties map[types.Hash]*dirtyNode
+
+	// retained are the roots of the latest blocks, oldest first
+	retained []types.Hash
+
+	// pending are the roots of the commits which aren't retained by a block (yet),
+	// mapped to the number of the retained blocks when they were written
+	pending map[types.Hash]uint64
+	blocks  uint64
+
+	closed bool
+}
+
+// NewPruningStorage creates a pruning storage on top of the disk storage
+func NewPruningStorage(disk Storage, config PruningConfig, logger hclog.Logger) *PruningStorage {
+	return &PruningStorage{
+		Storage: disk,
+		logger:  logger.Named("state-pruning"),
+		config:  config,
+		dirties: make(map[types.Hash]*dirtyNode),
+		pending: make(map[types.Hash]uint64),
+	}
+}
+
+// Get returns the node from memory if it hasn't been flushed, otherwise from the disk
+func (p *PruningStorage) Get(k []byte) ([]byte, bool) {
+	if len(k) == types.HashLength {
+		p.lock.RLock()
+		node, ok := p.dirties[types.BytesToHash(k)]
+		p.lock.RUnlock()
+
+		if ok {
+			return node.blob, true
+		}
+	}
+
+	return p.Storage.Get(k)
+}
+
+// Batch returns a batch which writes the nodes to memory
+func (p *PruningStorage) Batch() Batch {
+	return &pruningBatch{storage: p}
+}
+
+// CommitRoot retains the state root of the new head block and garbage collects
+// the nodes of the roots which are no longer retained.
+// The state is flushed to the disk every FlushInterval blocks
+func (p *PruningStorage) CommitRoot(number uint64, root types.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.blocks++
+
+	if _, ok := p.pending[root]; ok {
+		// the block takes over the reference of the commit
+		delete(p.pending, root)
+	} else if node, ok := p.dirties[root]; ok {
+		node.parents++
+	}
+
+	p.retained = append(p.retained, root)
+
+	before := len(p.dirties)
+
+	for uint64(len(p.retained)) > p.config.Retain {
+		p.dereference(p.retained[0])
+		p.retained = p.retained[1:]
+	}
+
+	// the commits which didn't end up in a block, e.g. the rejected proposals
+	for pendingRoot, written := range p.pending {
+		if p.blocks-written > p.config.Retain {
+			delete(p.pending, pendingRoot)
+			p.dereference(pendingRoot)
+		}
+	}
+
+	if collected := before - len(p.dirties); collected > 0 {
+		p.logger.Debug("garbage collected the state nodes", "nodes", collected, "dirty", len(p.dirties))
+	}
+
+	if number%p.config.FlushInterval == 0 {
+		flushed := p.flush(root)
+
+		p.logger.Debug("flushed the state", "block", number, "root", root, "nodes", flushed)
+	}
+}
+
+// Close flushes the retained states and closes the disk storage
+func (p *PruningStorage) Close() error {
+	p.lock.Lock()
+
+	p.closed = true
+
+	for _, root := range p.retained {
+		p.flush(root)
+	}
+
+	for root := range p.pending {
+		p.flush(root)
+	}
+
+	p.lock.Unlock()
+
+	return p.Storage.Close()
+}
+
+// insert adds the written nodes to memory.
+// The new nodes which aren't referenced by the other nodes are the roots of the commit
+func (p *PruningStorage) insert(nodes []batchEntry) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	inserted := make([]*dirtyNode, 0, len(nodes))
+	hashes := make([]types.Hash, 0, len(nodes))
+
+	for _, entry := range nodes {
+		hash := types.BytesToHash(entry.key)
+		if _, ok := p.dirties[hash]; ok {
+			continue
+		}
+
+		children, err := nodeReferences(entry.value)
+		if err != nil {
+			p.logger.Error("failed to decode the state node", "hash", hash, "err", err)
+		}
+
+		node := &dirtyNode{blob: entry.value, children: children}
+		p.dirties[hash] = node
+
+		inserted = append(inserted, node)
+		hashes = append(hashes, hash)
+	}
+
+	for _, node := range inserted {
+		for _, child := range node.children {
+			if childNode, ok := p.dirties[child]; ok {
+				childNode.parents++
+			}
+		}
+	}
+
+	for i, node := range inserted {
+		if node.parents == 0 {
+			node.parents++
+			p.pending[hashes[i]] = p.blocks
+		}
+	}
+}
+
+// dereference drops a reference to the node, removing the node from memory
+// together with its children once it is no longer referenced
+func (p *PruningStorage) dereference(hash types.Hash) {
+	node, ok := p.dirties[hash]
+	if !ok {
+		// flushed to the disk
+		return
+	}
+
+	if node.parents > 0 {
+		node.parents--
+	}
+
+	if node.parents != 0 {
+		return
+	}
+
+	delete(p.dirties, hash)
+
+	for _, child := range node.children {
+		p.dereference(child)
+	}
+}
+
+// flush writes the dirty nodes of the trie to the disk and removes them from memory.
+// Returns the number of the flushed nodes
+func (p *PruningStorage) flush(root types.Hash) int {
+	batch := p.Storage.Batch()
+	flushed := 0
+
+	var write func(hash types.Hash)
+
+	write = func(hash types.Hash) {
+		node, ok := p.dirties[hash]
+		if !ok {
+			return
+		}
+
+		for _, child := range node.children {
+			write(child)
+		}
+
+		batch.Put(hash.Bytes(), node.blob)
+		delete(p.dirties, hash)
+
+		flushed++
+	}
+
+	write(root)
+	batch.Write()
+
+	return flushed
+}
+
+// nodeReferences returns the hashes of the nodes referenced by the encoded node,
+// including the storage roots of the accounts in the leaves
+func nodeReferences(blob []byte) ([]types.Hash, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := decodeNode(v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		refs []types.Hash
+		walk func(n Node)
+	)
+
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *ShortNode:
+			walk(n.child)
+
+		case *FullNode:
+			for _, child := range n.children {
+				walk(child)
+			}
+
+			walk(n.value)
+
+		case *ValueNode:
+			if n.hash {
+				refs = append(refs, types.BytesToHash(n.buf))
+
+				return
+			}
+
+			// the values of the storage tries are not lists, so they don't decode as accounts
+			var account state.Account
+			if err := account.UnmarshalRlp(n.buf); err == nil && account.Root != types.EmptyRootHash {
+				refs = append(refs, account.Root)
+			}
+		}
+	}
+
+	walk(n)
+
+	return refs, nil
+}
+
+type batchEntry struct {
+	key   []byte
+	value []byte
+}
+
+// pruningBatch is a batch write to the memory of the pruning storage
+type pruningBatch struct {
+	storage *PruningStorage
+	nodes   []batchEntry
+}
+
+func (b *pruningBatch) Put(k, v []byte) {
+	// the hasher reuses the buffers
+	b.nodes = append(b.nodes, batchEntry{
+		key:   append([]byte{}, k...),
+		value: append([]byte{}, v...),
+	})
+}
+
+func (b *pruningBatch) Write() {
+	b.storage.insert(b.nodes)
+	b.nodes = nil
+}
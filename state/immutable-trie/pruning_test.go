@@ -0,0 +1,130 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	pruningAddr1 = types.StringToAddress("1")
+	pruningAddr2 = types.StringToAddress("2")
+)
+
+// commitPruningBlock commits a state with the nonce and a storage slot of the account set to the number
+func commitPruningBlock(t *testing.T, snap state.Snapshot, addr types.Address, number uint64) (state.Snapshot, types.Hash) {
+	t.Helper()
+
+	txn := state.NewTxn(snap)
+	txn.SetNonce(addr, number)
+	txn.SetState(addr, types.BytesToHash([]byte{byte(number)}), types.BytesToHash([]byte{byte(number)}))
+
+	snap, root := snap.Commit(txn.Commit(false))
+
+	return snap, types.BytesToHash(root)
+}
+
+// assertPruningState checks the state written by commitPruningBlock is available at the root
+func assertPruningState(t *testing.T, storage Storage, root types.Hash, addr types.Address, number uint64) {
+	t.Helper()
+
+	// a new state, so the tries aren't cached
+	snap, err := NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	txn := state.NewTxn(snap)
+	assert.Equal(t, number, txn.GetNonce(addr))
+	assert.Equal(
+		t,
+		types.BytesToHash([]byte{byte(number)}),
+		txn.GetState(addr, types.BytesToHash([]byte{byte(number)})),
+	)
+}
+
+func TestPruningStorage_Retain(t *testing.T) {
+	t.Parallel()
+
+	disk := NewMemoryStorage()
+	storage := NewPruningStorage(disk, PruningConfig{FlushInterval: 100, Retain: 2}, hclog.NewNullLogger())
+
+	snap := NewState(storage).NewSnapshot()
+
+	// the storage of the account isn't changed after the first block
+	snap, _ = commitPruningBlock(t, snap, pruningAddr2, 1)
+
+	roots := make([]types.Hash, 0)
+
+	for number := uint64(1); number <= 6; number++ {
+		var root types.Hash
+
+		snap, root = commitPruningBlock(t, snap, pruningAddr1, number)
+		storage.CommitRoot(number, root)
+
+		roots = append(roots, root)
+
+		if number == 3 {
+			// a proposal which doesn't end up in a block
+			_, rejected := commitPruningBlock(t, snap, pruningAddr1, 100)
+			assertPruningState(t, storage, rejected, pruningAddr1, 100)
+
+			defer func() {
+				_, err := NewState(storage).NewSnapshotAt(rejected)
+				assert.Error(t, err)
+			}()
+		}
+	}
+
+	// the last roots are retained
+	assertPruningState(t, storage, roots[4], pruningAddr1, 5)
+	assertPruningState(t, storage, roots[5], pruningAddr1, 6)
+	assertPruningState(t, storage, roots[5], pruningAddr2, 1)
+
+	// the older roots are garbage collected
+	for _, root := range roots[:4] {
+		_, err := NewState(storage).NewSnapshotAt(root)
+		assert.Error(t, err)
+	}
+
+	// nothing has been flushed
+	_, ok := disk.Get(roots[5].Bytes())
+	assert.False(t, ok)
+}
+
+func TestPruningStorage_Flush(t *testing.T) {
+	t.Parallel()
+
+	disk := NewMemoryStorage()
+	storage := NewPruningStorage(disk, PruningConfig{FlushInterval: 4, Retain: 2}, hclog.NewNullLogger())
+
+	snap := NewState(storage).NewSnapshot()
+	roots := make([]types.Hash, 0)
+
+	for number := uint64(1); number <= 6; number++ {
+		var root types.Hash
+
+		snap, root = commitPruningBlock(t, snap, pruningAddr1, number)
+		storage.CommitRoot(number, root)
+
+		roots = append(roots, root)
+	}
+
+	// the state of the flushed block stays available
+	assertPruningState(t, disk, roots[3], pruningAddr1, 4)
+	assertPruningState(t, storage, roots[3], pruningAddr1, 4)
+
+	_, err := NewState(disk).NewSnapshotAt(roots[5])
+	assert.Error(t, err)
+
+	// the retained states are flushed on close
+	require.NoError(t, storage.Close())
+
+	assertPruningState(t, disk, roots[4], pruningAddr1, 5)
+	assertPruningState(t, disk, roots[5], pruningAddr1, 6)
+
+	_, err = NewState(disk).NewSnapshotAt(roots[2])
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package itrie
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -12,29 +13,29 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	root  types.Hash
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
 
 func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.Hash) types.Hash {
-	var (
-		err  error
-		trie *Trie
-	)
-
 	if root == emptyStateHash {
-		trie = s.state.newTrie()
-	} else {
-		trie, err = s.state.newTrieAt(root)
-		if err != nil {
-			return types.Hash{}
-		}
+		return types.Hash{}
 	}
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
-	val, ok := trie.Get(key, s.state.storage)
+	val, ok := s.state.flatStorageSlot(s.root, crypto.Keccak256(addr.Bytes()), key)
 	if !ok {
+		trie, err := s.state.newTrieAt(root)
+		if err != nil {
+			return types.Hash{}
+		}
+
+		val, _ = trie.Get(key, s.state.storage)
+	}
+
+	if val == nil {
 		return types.Hash{}
 	}
 
@@ -56,8 +57,12 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	data, ok := s.state.flatAccount(s.root, key)
 	if !ok {
+		data, _ = s.trie.Get(key, s.state.storage)
+	}
+
+	if data == nil {
 		return nil, nil
 	}
 
@@ -73,9 +78,58 @@ func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
 
+func (s *Snapshot) GetProof(addr types.Address, storageKeys []types.Hash) (*state.AccountProof, error) {
+	accountProof, err := s.trie.Prove(hashit(addr.Bytes()), s.state.storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove account %s: %w", addr, err)
+	}
+
+	account, err := s.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	storageRoot := types.EmptyRootHash
+	if account != nil {
+		storageRoot = account.Root
+	}
+
+	storageTrie, err := s.state.newTrieAt(storageRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &state.AccountProof{
+		Account:      account,
+		Proof:        accountProof,
+		StorageProof: make([]*state.StorageProof, len(storageKeys)),
+	}
+
+	for i, key := range storageKeys {
+		proof, err := storageTrie.Prove(hashit(key.Bytes()), s.state.storage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove storage key %s: %w", key, err)
+		}
+
+		res.StorageProof[i] = &state.StorageProof{
+			Key:   key,
+			Value: s.GetStorage(addr, storageRoot, key),
+			Proof: proof,
+		}
+	}
+
+	return res, nil
+}
+
 func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	batch := s.state.storage.Batch()
 
+	// the changes are tracked only if the flat state is used
+	var diff *flatDiff
+	if s.state.flat != nil {
+		diff = newFlatDiff()
+	}
+
 	tt := s.trie.Txn(s.state.storage)
 	tt.batch = batch
 
@@ -86,8 +140,18 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	defer stateArenaPool.Put(ar1)
 
 	for _, obj := range objs {
+		accountHash := hashit(obj.Address.Bytes())
+
+		if diff != nil && (obj.Deleted || obj.Root == emptyStateHash) {
+			// the account is deleted or recreated, its previous storage is wiped
+			if prev, err := s.GetAccount(obj.Address); err != nil || prev != nil && prev.Root != emptyStateHash {
+				diff.destruct(accountHash)
+			}
+		}
+
 		if obj.Deleted {
-			tt.Delete(hashit(obj.Address.Bytes()))
+			tt.Delete(accountHash)
+			diff.setAccount(accountHash, nil)
 		} else {
 			account := state.Account{
 				Balance:  obj.Balance,
@@ -109,9 +173,13 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+						diff.setSlot(accountHash, k, nil)
 					} else {
 						vv := ar1.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
-						localTxn.Insert(k, vv.MarshalTo(nil))
+						val := vv.MarshalTo(nil)
+
+						localTxn.Insert(k, val)
+						diff.setSlot(accountHash, k, val)
 					}
 				}
 
@@ -131,7 +199,8 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			tt.Insert(accountHash, data)
+			diff.setAccount(accountHash, data)
 			arena.Reset()
 		}
 	}
@@ -145,5 +214,9 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root
+	if diff != nil {
+		s.state.flat.update(s.root, types.BytesToHash(root), diff)
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, root: types.BytesToHash(root)}, root
 }
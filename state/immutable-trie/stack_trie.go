@@ -0,0 +1,101 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+)
+
+var errStackTrieUnordered = errors.New("keys are not inserted in ascending order")
+
+// StackTrie computes the root of a trie from the keys inserted in ascending order.
+// The subtrees on the left of the last inserted key can't change anymore,
+// so they are collapsed into their hashes and only the path of the last key is kept in memory
+type StackTrie struct {
+	txn     *Txn
+	lastKey []byte
+}
+
+// NewStackTrie creates an empty stack trie
+func NewStackTrie() *StackTrie {
+	return &StackTrie{
+		txn: NewTrie().Txn(nil),
+	}
+}
+
+// Insert adds the key to the trie, the key must be greater than the previously inserted one
+func (s *StackTrie) Insert(key, value []byte) error {
+	if s.lastKey != nil && bytes.Compare(key, s.lastKey) <= 0 {
+		return fmt.Errorf("%w: %x after %x", errStackTrieUnordered, key, s.lastKey)
+	}
+
+	s.lastKey = append(s.lastKey[:0], key...)
+
+	search := bytesToHexNibbles(key)
+	s.txn.root = s.txn.insert(s.txn.root, search, value)
+
+	return s.collapse(search)
+}
+
+// Hash returns the root of the trie
+func (s *StackTrie) Hash() ([]byte, error) {
+	return s.txn.Hash()
+}
+
+// collapse replaces the subtrees on the left of the search path with references to their hashes.
+// The subtrees with an encoding shorter than a hash are embedded in their parent, so they are kept
+func (s *StackTrie) collapse(search []byte) error {
+	h, ok := hasherPool.Get().(*hasher)
+	if !ok {
+		return errors.New("invalid type assertion")
+	}
+
+	defer hasherPool.Put(h)
+
+	node := s.txn.root
+
+	for {
+		switch n := node.(type) {
+		case *ShortNode:
+			if !bytes.HasPrefix(search, n.key) {
+				return nil
+			}
+
+			search = search[len(n.key):]
+			node = n.child
+
+		case *FullNode:
+			if len(search) == 0 || search[0] == 16 {
+				return nil
+			}
+
+			for i := byte(0); i < search[0]; i++ {
+				switch n.children[i].(type) {
+				case *ShortNode, *FullNode:
+				default:
+					continue
+				}
+
+				arena, _ := h.AcquireArena()
+				val := s.txn.hash(n.children[i], h, arena, 0)
+
+				if val.Type() == fastrlp.TypeBytes && val.Len() == 32 {
+					n.children[i] = &ValueNode{
+						hash: true,
+						buf:  append([]byte{}, val.Raw()...),
+					}
+				}
+
+				h.ReleaseArenas(0)
+			}
+
+			node = n.children[search[0]]
+			search = search[1:]
+
+		default:
+			return nil
+		}
+	}
+}
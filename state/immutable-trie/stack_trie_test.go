@@ -0,0 +1,132 @@
+package itrie
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackTrie_Hash(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		keys    int
+		keyLen  int
+		valueFn func(r *rand.Rand) []byte
+	}{
+		{
+			name:   "empty",
+			keys:   0,
+			keyLen: 32,
+		},
+		{
+			name:   "single key",
+			keys:   1,
+			keyLen: 32,
+			valueFn: func(r *rand.Rand) []byte {
+				return []byte{0x1}
+			},
+		},
+		{
+			name:   "hashed keys",
+			keys:   2000,
+			keyLen: 32,
+			valueFn: func(r *rand.Rand) []byte {
+				v := make([]byte, 70)
+				r.Read(v)
+
+				return v
+			},
+		},
+		{
+			// the short leaves are embedded in their parents instead of being hashed
+			name:   "embedded nodes",
+			keys:   500,
+			keyLen: 2,
+			valueFn: func(r *rand.Rand) []byte {
+				return []byte{byte(r.Intn(256))}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := rand.New(rand.NewSource(1)) //nolint:gosec
+
+			entries := map[string][]byte{}
+
+			for len(entries) < c.keys {
+				k := make([]byte, c.keyLen)
+				r.Read(k)
+
+				entries[string(k)] = c.valueFn(r)
+			}
+
+			keys := make([]string, 0, len(entries))
+			for k := range entries {
+				keys = append(keys, k)
+			}
+
+			sort.Strings(keys)
+
+			expected := NewTrie().Txn(nil)
+			stack := NewStackTrie()
+
+			for _, k := range keys {
+				expected.Insert([]byte(k), entries[k])
+				require.NoError(t, stack.Insert([]byte(k), entries[k]))
+			}
+
+			expectedRoot, err := expected.Hash()
+			require.NoError(t, err)
+
+			root, err := stack.Hash()
+			require.NoError(t, err)
+
+			assert.True(t, bytes.Equal(expectedRoot, root))
+		})
+	}
+}
+
+func TestStackTrie_Collapse(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStackTrie()
+
+	for i := 0; i < 16; i++ {
+		key := bytes.Repeat([]byte{byte(i << 4)}, 32)
+		require.NoError(t, stack.Insert(key, bytes.Repeat([]byte{0x1}, 32)))
+	}
+
+	root, ok := stack.txn.root.(*FullNode)
+	require.True(t, ok)
+
+	// only the path of the last key is kept
+	for i := 0; i < 15; i++ {
+		ref, ok := root.children[i].(*ValueNode)
+		require.True(t, ok)
+		assert.True(t, ref.hash)
+	}
+
+	_, ok = root.children[15].(*ShortNode)
+	assert.True(t, ok)
+}
+
+func TestStackTrie_Unordered(t *testing.T) {
+	t.Parallel()
+
+	stack := NewStackTrie()
+
+	require.NoError(t, stack.Insert([]byte{0x2}, []byte{0x1}))
+	require.ErrorIs(t, stack.Insert([]byte{0x1}, []byte{0x1}), errStackTrieUnordered)
+	require.ErrorIs(t, stack.Insert([]byte{0x2}, []byte{0x1}), errStackTrieUnordered)
+}
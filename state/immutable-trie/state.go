@@ -12,6 +12,7 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+	flat    *FlatState
 }
 
 func NewState(storage Storage) *State {
@@ -26,7 +27,7 @@ func NewState(storage Storage) *State {
 }
 
 func (s *State) NewSnapshot() state.Snapshot {
-	return &Snapshot{state: s, trie: s.newTrie()}
+	return &Snapshot{state: s, trie: s.newTrie(), root: types.EmptyRootHash}
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
@@ -35,7 +36,32 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, root: root}, nil
+}
+
+// SetFlatState sets the flat state the snapshots read the accounts and the storage from
+func (s *State) SetFlatState(flat *FlatState) {
+	s.flat = flat
+}
+
+// flatAccount returns the encoded account from the flat state at the root,
+// false if it has to be read from the trie
+func (s *State) flatAccount(root types.Hash, hash []byte) ([]byte, bool) {
+	if s.flat == nil {
+		return nil, false
+	}
+
+	return s.flat.account(root, hash)
+}
+
+// flatStorageSlot returns the encoded storage slot from the flat state at the root,
+// false if it has to be read from the trie
+func (s *State) flatStorageSlot(root types.Hash, accountHash, slotHash []byte) ([]byte, bool) {
+	if s.flat == nil {
+		return nil, false
+	}
+
+	return s.flat.storageSlot(root, accountHash, slotHash)
 }
 
 func (s *State) newTrie() *Trie {
@@ -85,6 +85,8 @@ func init() {
 	// store
 	register(SLOAD, handler{opSload, 1, 0})
 	register(SSTORE, handler{opSStore, 2, 0})
+	register(TLOAD, handler{opTload, 1, 100})
+	register(TSTORE, handler{opTstore, 2, 100})
 
 	register(SHA3, handler{opSha3, 2, 30})
 
@@ -109,12 +111,14 @@ func init() {
 	register(PC, handler{opPC, 0, 2})
 	register(MSIZE, handler{opMSize, 0, 2})
 	register(GAS, handler{opGas, 0, 2})
+	register(PUSH0, handler{opPush0, 0, 2})
 
 	register(EXTCODECOPY, handler{opExtCodeCopy, 4, 0})
 
 	register(CALLDATACOPY, handler{opCallDataCopy, 3, 3})
 	register(RETURNDATACOPY, handler{opReturnDataCopy, 3, 3})
 	register(CODECOPY, handler{opCodeCopy, 3, 3})
+	register(MCOPY, handler{opMCopy, 3, 3})
 
 	// block information
 	register(BLOCKHASH, handler{opBlockHash, 1, 20})
@@ -22,6 +22,37 @@ var (
 	wordSize = big.NewInt(32)
 )
 
+// Gas costs of the state access introduced in eip-2929
+const (
+	coldAccountAccessCost uint64 = 2600
+	coldSloadCost         uint64 = 2100
+	warmStorageReadCost   uint64 = 100
+)
+
+// addressAccessCost returns the gas cost of accessing the given address
+// and adds the address to the access list of the transaction (eip-2929)
+func (c *state) addressAccessCost(addr types.Address) uint64 {
+	if c.host.ContainsAccessListAddress(addr) {
+		return warmStorageReadCost
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return coldAccountAccessCost
+}
+
+// slotAccessCost returns the extra gas cost of accessing the given storage slot
+// of the current contract and adds the slot to the access list of the transaction (eip-2929)
+func (c *state) slotAccessCost(slot types.Hash) uint64 {
+	if _, slotOk := c.host.ContainsAccessListSlot(c.msg.Address, slot); slotOk {
+		return 0
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return coldSloadCost
+}
+
 func opAdd(c *state) {
 	a := c.pop()
 	b := c.top()
@@ -465,7 +496,10 @@ func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = warmStorageReadCost + c.slotAccessCost(bigToHash(loc))
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -482,6 +516,38 @@ func opSload(c *state) {
 	loc.SetBytes(val.Bytes())
 }
 
+func opTload(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientStorage(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTstore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 func opSStore(c *state) {
 	if c.inStaticCall() {
 		c.exit(errWriteProtection)
@@ -500,12 +566,20 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
+	if c.config.Berlin {
+		// eip-2929
+		cost = c.slotAccessCost(key)
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 
 	switch status {
 	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -515,10 +589,18 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageModified:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 
 	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
 			cost = 800
 		} else if legacyGasMetering {
@@ -528,10 +610,15 @@ func opSStore(c *state) {
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
+		cost += 20000
 
 	case runtime.StorageDeleted:
-		cost = 5000
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
+		} else {
+			cost = 5000
+		}
 	}
 
 	if !c.consumeGas(cost) {
@@ -575,7 +662,10 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -658,7 +748,10 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +786,10 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +863,10 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.addressAccessCost(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -802,6 +901,33 @@ func opCallDataCopy(c *state) {
 	}
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dst := c.pop()
+	src := c.pop()
+	length := c.pop()
+
+	// the memory is expanded to cover both the source and the destination areas
+	if !c.allocateMemory(src, length) || !c.allocateMemory(dst, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		s, d := src.Uint64(), dst.Uint64()
+		copy(c.memory[d:d+size], c.memory[s:s+size])
+	}
+}
+
 func opReturnDataCopy(c *state) {
 	if !c.config.Byzantium {
 		c.exit(errOpCodeNotFound)
@@ -943,6 +1069,13 @@ func opSelfDestruct(c *state) {
 		}
 	}
 
+	// eip-2929
+	if c.config.Berlin && !c.host.ContainsAccessListAddress(address) {
+		c.host.AddAddressToAccessList(address)
+
+		gas += coldAccountAccessCost
+	}
+
 	if !c.consumeGas(gas) {
 		return
 	}
@@ -975,6 +1108,16 @@ func opJumpi(c *state) {
 func opJumpDest(c *state) {
 }
 
+func opPush0(c *state) {
+	if !c.config.Shanghai {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().Set(zero)
+}
+
 func opPush(n int) instruction {
 	return func(c *state) {
 		ins := c.code
@@ -1094,6 +1237,9 @@ func opCreate(op OpCode) instruction {
 		}
 
 		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
@@ -1224,7 +1370,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gasCost = c.addressAccessCost(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -1362,6 +1511,22 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 		}
 	}
 
+	if c.config.Shanghai {
+		size := length.Uint64()
+
+		// The initcode over the size limit aborts the calling frame (eip-3860)
+		if size > runtime.MaxInitCodeSize {
+			c.exit(runtime.ErrMaxInitCodeSizeExceeded)
+
+			return nil, nil
+		}
+
+		// Consume initcode gas cost (eip-3860)
+		if !c.consumeGas(((size + 31) / 32) * runtime.InitCodeWordGas) {
+			return nil, nil
+		}
+	}
+
 	// Calculate and consume gas for the call
 	gas := c.gas
 
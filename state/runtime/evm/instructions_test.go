@@ -88,6 +88,33 @@ func TestIsZero(t *testing.T) {
 	})
 }
 
+func TestPush0(t *testing.T) {
+	t.Run("Shanghai fork enabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+
+		opPush0(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, 1, s.stackSize())
+		assert.Equal(t, uint64(0), s.pop().Uint64())
+	})
+
+	t.Run("Shanghai fork disabled", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{London: true}
+
+		opPush0(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+		assert.Equal(t, 0, s.stackSize())
+	})
+}
+
 func TestMStore(t *testing.T) {
 	s, closeFn := getState()
 	defer closeFn()
@@ -103,9 +130,59 @@ func TestMStore(t *testing.T) {
 
 type mockHostForInstructions struct {
 	mockHost
-	nonce       uint64
-	code        []byte
-	callxResult *runtime.ExecutionResult
+	nonce         uint64
+	code          []byte
+	callxResult   *runtime.ExecutionResult
+	storage       map[types.Hash]types.Hash
+	accessedAddrs map[types.Address]struct{}
+	accessedSlots map[types.Hash]struct{}
+	transient     map[types.Hash]types.Hash
+}
+
+func (m *mockHostForInstructions) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(addr types.Address) {
+	if m.accessedAddrs == nil {
+		m.accessedAddrs = map[types.Address]struct{}{}
+	}
+
+	m.accessedAddrs[addr] = struct{}{}
+}
+
+func (m *mockHostForInstructions) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.AddAddressToAccessList(addr)
+
+	if m.accessedSlots == nil {
+		m.accessedSlots = map[types.Hash]struct{}{}
+	}
+
+	m.accessedSlots[slot] = struct{}{}
+}
+
+func (m *mockHostForInstructions) ContainsAccessListAddress(addr types.Address) bool {
+	_, ok := m.accessedAddrs[addr]
+
+	return ok
+}
+
+func (m *mockHostForInstructions) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	_, slotOk := m.accessedSlots[slot]
+
+	return m.ContainsAccessListAddress(addr), slotOk
+}
+
+func (m *mockHostForInstructions) GetTransientStorage(_ types.Address, key types.Hash) types.Hash {
+	return m.transient[key]
+}
+
+func (m *mockHostForInstructions) SetTransientStorage(_ types.Address, key types.Hash, value types.Hash) {
+	if m.transient == nil {
+		m.transient = map[types.Hash]types.Hash{}
+	}
+
+	m.transient[key] = value
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -386,6 +463,30 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreate_MaxInitCodeSize(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.msg = &runtime.Contract{Address: addr1}
+	s.config = &chain.ForksInTime{Shanghai: true}
+	s.gas = 1000000
+	s.host = &mockHostForInstructions{
+		callxResult: &runtime.ExecutionResult{},
+	}
+
+	s.push(big.NewInt(runtime.MaxInitCodeSize + 1)) // length
+	s.push(big.NewInt(0x00))                        // offset
+	s.push(big.NewInt(0x00))                        // value
+
+	opCreate(CREATE)(s)
+
+	// the calling frame is aborted instead of the child frame failing
+	assert.True(t, s.stop)
+	assert.ErrorIs(t, s.err, runtime.ErrMaxInitCodeSizeExceeded)
+}
+
 func Test_opReturnDataCopy(t *testing.T) {
 	t.Parallel()
 
@@ -629,7 +730,7 @@ func Test_opCall(t *testing.T) {
 			},
 			config: &allEnabledForks,
 			initState: &state{
-				gas: 1000,
+				gas: 10000,
 				sp:  6,
 				stack: []*big.Int{
 					big.NewInt(0x00), // outSize
@@ -677,3 +778,123 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func TestSloadAccessList(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	key := types.StringToHash("1")
+
+	s.msg = &runtime.Contract{Address: addr1}
+	s.config = &allEnabledForks
+	s.host = &mockHostForInstructions{
+		storage: map[types.Hash]types.Hash{key: types.StringToHash("2")},
+	}
+	s.gas = 10000
+
+	// cold access
+	s.push(new(big.Int).SetBytes(key.Bytes()))
+	opSload(s)
+
+	assert.Equal(t, types.StringToHash("2").Bytes(), bigToHash(s.pop()).Bytes())
+	assert.Equal(t, 10000-warmStorageReadCost-coldSloadCost, s.gas)
+
+	// warm access
+	s.push(new(big.Int).SetBytes(key.Bytes()))
+	opSload(s)
+
+	assert.Equal(t, 10000-2*warmStorageReadCost-coldSloadCost, s.gas)
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Parallel()
+
+	key := types.StringToHash("1")
+	value := types.StringToHash("2")
+
+	t.Run("store and load", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &allEnabledForks
+		s.host = &mockHostForInstructions{}
+
+		s.push(new(big.Int).SetBytes(value.Bytes()))
+		s.push(new(big.Int).SetBytes(key.Bytes()))
+		opTstore(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, 0, s.stackSize())
+
+		s.push(new(big.Int).SetBytes(key.Bytes()))
+		opTload(s)
+
+		assert.Equal(t, value.Bytes(), bigToHash(s.pop()).Bytes())
+	})
+
+	t.Run("store in static call", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.config = &allEnabledForks
+		s.host = &mockHostForInstructions{}
+
+		s.push(new(big.Int).SetBytes(value.Bytes()))
+		s.push(new(big.Int).SetBytes(key.Bytes()))
+		opTstore(s)
+
+		assert.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("Cancun fork disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &chain.ForksInTime{Shanghai: true}
+		s.host = &mockHostForInstructions{}
+
+		s.push(new(big.Int).SetBytes(key.Bytes()))
+		opTload(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+func TestMCopy(t *testing.T) {
+	t.Parallel()
+
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.config = &allEnabledForks
+	s.gas = 1000
+
+	// store a word at offset 0
+	s.push(big.NewInt(0xff))
+	s.push(big.NewInt(0))
+	opMStore(s)
+
+	// copy it to offset 32
+	s.push(big.NewInt(32)) // length
+	s.push(big.NewInt(0))  // src
+	s.push(big.NewInt(32)) // dst
+	opMCopy(s)
+
+	assert.NoError(t, s.err)
+	assert.Len(t, s.memory, 64)
+	assert.Equal(t, s.memory[0:32], s.memory[32:64])
+	assert.Equal(t, byte(0xff), s.memory[63])
+	// 2 words of memory (6 gas) and 1 word of copy (3 gas)
+	assert.Equal(t, uint64(1000-6-3), s.gas)
+}
@@ -200,6 +200,18 @@ const (
 	// JUMPDEST corresponds to a possible jump destination
 	JUMPDEST = 0x5B
 
+	// TLOAD loads a word from the transient storage
+	TLOAD = 0x5C
+
+	// TSTORE stores a word to the transient storage
+	TSTORE = 0x5D
+
+	// MCOPY copies an area of memory to another area of memory
+	MCOPY = 0x5E
+
+	// PUSH0 pushes a 0 value onto the stack
+	PUSH0 = 0x5F
+
 	// PUSH1 pushes a 1-byte value onto the stack
 	PUSH1 = 0x60
 
@@ -324,6 +336,10 @@ var opCodeToString = map[OpCode]string{
 	MSIZE:          "MSIZE",
 	GAS:            "GAS",
 	JUMPDEST:       "JUMPDEST",
+	TLOAD:          "TLOAD",
+	TSTORE:         "TSTORE",
+	MCOPY:          "MCOPY",
+	PUSH0:          "PUSH0",
 	CREATE:         "CREATE",
 	CALL:           "CALL",
 	RETURN:         "RETURN",
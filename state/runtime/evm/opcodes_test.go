@@ -13,6 +13,7 @@ func TestOpcodesString(t *testing.T) {
 
 	assert(PUSH1, "PUSH1")
 	assert(PUSH32, "PUSH32")
+	assert(PUSH0, "PUSH0")
 
 	assert(LOG0, "LOG0")
 	assert(LOG4, "LOG4")
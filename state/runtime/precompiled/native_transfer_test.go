@@ -193,3 +193,33 @@ func (d dummyHost) GetTracer() runtime.VMTracer {
 func (d dummyHost) GetRefund() uint64 {
 	return 0
 }
+
+func (d dummyHost) AddAddressToAccessList(addr types.Address) {
+	d.t.Fatalf("AddAddressToAccessList is not implemented")
+}
+
+func (d dummyHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	d.t.Fatalf("AddSlotToAccessList is not implemented")
+}
+
+func (d dummyHost) ContainsAccessListAddress(addr types.Address) bool {
+	d.t.Fatalf("ContainsAccessListAddress is not implemented")
+
+	return false
+}
+
+func (d dummyHost) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	d.t.Fatalf("ContainsAccessListSlot is not implemented")
+
+	return false, false
+}
+
+func (d dummyHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	d.t.Fatalf("GetTransientStorage is not implemented")
+
+	return types.Hash{}
+}
+
+func (d dummyHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	d.t.Fatalf("SetTransientStorage is not implemented")
+}
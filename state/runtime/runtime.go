@@ -77,6 +77,12 @@ type Host interface {
 	Transfer(from types.Address, to types.Address, amount *big.Int) error
 	GetTracer() VMTracer
 	GetRefund() uint64
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
+	ContainsAccessListAddress(addr types.Address) bool
+	ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
 }
 
 type VMTracer interface {
@@ -135,6 +141,7 @@ var (
 	ErrNotEnoughFunds           = errors.New("not enough funds")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrMaxCodeSizeExceeded      = errors.New("evm: max code size exceeded")
+	ErrMaxInitCodeSizeExceeded  = errors.New("evm: max initcode size exceeded")
 	ErrContractAddressCollision = errors.New("contract address collision")
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
@@ -144,6 +151,14 @@ var (
 	ErrNotAuth                  = errors.New("not in allow list")
 )
 
+const (
+	// MaxInitCodeSize is the maximum size of the initcode of a contract creation (EIP-3860)
+	MaxInitCodeSize = 2 * 24576
+
+	// InitCodeWordGas is the gas charged per word of initcode of a contract creation (EIP-3860)
+	InitCodeWordGas uint64 = 2
+)
+
 type CallType int
 
 const (
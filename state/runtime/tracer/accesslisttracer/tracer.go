@@ -0,0 +1,262 @@
+package accesslisttracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// accessList is an insertion ordered set of addresses and their storage keys
+type accessList struct {
+	addresses []types.Address
+	slots     map[types.Address][]types.Hash
+	seen      map[types.Address]map[types.Hash]struct{}
+}
+
+func newAccessList(initial types.TxAccessList) *accessList {
+	al := &accessList{
+		slots: make(map[types.Address][]types.Hash),
+		seen:  make(map[types.Address]map[types.Hash]struct{}),
+	}
+
+	for _, tuple := range initial {
+		al.addAddress(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			al.addSlot(tuple.Address, key)
+		}
+	}
+
+	return al
+}
+
+func (al *accessList) addAddress(addr types.Address) {
+	if _, ok := al.seen[addr]; ok {
+		return
+	}
+
+	al.seen[addr] = make(map[types.Hash]struct{})
+	al.addresses = append(al.addresses, addr)
+}
+
+func (al *accessList) addSlot(addr types.Address, slot types.Hash) {
+	al.addAddress(addr)
+
+	if _, ok := al.seen[addr][slot]; ok {
+		return
+	}
+
+	al.seen[addr][slot] = struct{}{}
+	al.slots[addr] = append(al.slots[addr], slot)
+}
+
+func (al *accessList) toTxAccessList() types.TxAccessList {
+	res := make(types.TxAccessList, 0, len(al.addresses))
+
+	for _, addr := range al.addresses {
+		keys := make([]types.Hash, len(al.slots[addr]))
+		copy(keys, al.slots[addr])
+
+		res = append(res, types.AccessTuple{
+			Address:     addr,
+			StorageKeys: keys,
+		})
+	}
+
+	return res
+}
+
+// AccessListTracer records the addresses and storage slots touched during execution
+// in order to build an EIP-2930 access list for the traced transaction
+type AccessListTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	excluded map[types.Address]struct{}
+	initial  types.TxAccessList
+	list     *accessList
+
+	gasLimit    uint64
+	consumedGas uint64
+	err         error
+}
+
+// NewAccessListTracer creates a new tracer, seeded with the given access list.
+// Excluded addresses (sender, recipient, precompiles) are never added to the list
+func NewAccessListTracer(initial types.TxAccessList, excluded []types.Address) *AccessListTracer {
+	t := &AccessListTracer{
+		cancelLock: sync.RWMutex{},
+		excluded:   make(map[types.Address]struct{}, len(excluded)),
+		initial:    initial.Copy(),
+		list:       newAccessList(initial),
+	}
+
+	for _, addr := range excluded {
+		t.excluded[addr] = struct{}{}
+	}
+
+	return t
+}
+
+func (t *AccessListTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *AccessListTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *AccessListTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.list = newAccessList(t.initial)
+	t.gasLimit = 0
+	t.consumedGas = 0
+	t.err = nil
+}
+
+func (t *AccessListTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *AccessListTracer) TxEnd(gasLeft uint64) {
+	t.consumedGas = t.gasLimit - gasLeft
+}
+
+func (t *AccessListTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *AccessListTracer) CallEnd(
+	depth int,
+	output []byte,
+	err error,
+) {
+	if depth == 1 {
+		t.err = err
+	}
+}
+
+func (t *AccessListTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp < 1 {
+			return
+		}
+
+		t.list.addSlot(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp < 1 {
+			return
+		}
+
+		t.addAddress(types.BytesToAddress(stack[sp-1].Bytes()))
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp < 2 {
+			return
+		}
+
+		t.addAddress(types.BytesToAddress(stack[sp-2].Bytes()))
+	}
+}
+
+func (t *AccessListTracer) addAddress(addr types.Address) {
+	if _, ok := t.excluded[addr]; ok {
+		return
+	}
+
+	t.list.addAddress(addr)
+}
+
+func (t *AccessListTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+// AccessList returns the access list collected so far
+func (t *AccessListTracer) AccessList() types.TxAccessList {
+	return t.list.toTxAccessList()
+}
+
+// GasUsed returns the gas consumed by the traced transaction
+func (t *AccessListTracer) GasUsed() uint64 {
+	return t.consumedGas
+}
+
+// Err returns the execution error of the top-level call, if any
+func (t *AccessListTracer) Err() error {
+	return t.err
+}
+
+// Equal reports whether the collected access list matches the given one, ignoring order
+func (t *AccessListTracer) Equal(other types.TxAccessList) bool {
+	otherList := newAccessList(other)
+
+	if len(otherList.addresses) != len(t.list.addresses) {
+		return false
+	}
+
+	for addr, slots := range t.list.seen {
+		otherSlots, ok := otherList.seen[addr]
+		if !ok || len(otherSlots) != len(slots) {
+			return false
+		}
+
+		for slot := range slots {
+			if _, ok := otherSlots[slot]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (t *AccessListTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return t.AccessList(), nil
+}
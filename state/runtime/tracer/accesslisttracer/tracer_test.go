@@ -0,0 +1,103 @@
+package accesslisttracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testContract = types.StringToAddress("2")
+	testCallee   = types.StringToAddress("3")
+	testSlot     = types.StringToHash("4")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func TestAccessListTracer_CaptureState(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewAccessListTracer(nil, []types.Address{testFrom})
+
+	slot := new(big.Int).SetBytes(testSlot.Bytes())
+	callee := new(big.Int).SetBytes(testCallee.Bytes())
+	from := new(big.Int).SetBytes(testFrom.Bytes())
+
+	tracer.CaptureState(nil, []*big.Int{slot}, evm.SLOAD, testContract, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(1), slot}, evm.SSTORE, testContract, 2, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{callee, big.NewInt(100)}, evm.STATICCALL, testContract, 2, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{from}, evm.BALANCE, testContract, 1, nil, &mockState{})
+	// stack underflow is ignored
+	tracer.CaptureState(nil, []*big.Int{}, evm.EXTCODESIZE, testContract, 0, nil, &mockState{})
+
+	expected := types.TxAccessList{
+		{Address: testContract, StorageKeys: []types.Hash{testSlot}},
+		{Address: testCallee, StorageKeys: []types.Hash{}},
+	}
+
+	assert.Equal(t, expected, tracer.AccessList())
+	assert.True(t, tracer.Equal(types.TxAccessList{expected[1], expected[0]}))
+	assert.False(t, tracer.Equal(expected[:1]))
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestAccessListTracer_Clear(t *testing.T) {
+	t.Parallel()
+
+	initial := types.TxAccessList{
+		{Address: testCallee, StorageKeys: []types.Hash{testSlot}},
+	}
+
+	tracer := NewAccessListTracer(initial, nil)
+
+	tracer.TxStart(100000)
+	tracer.CaptureState(
+		nil,
+		[]*big.Int{new(big.Int).SetBytes(testSlot.Bytes())},
+		evm.SLOAD, testContract, 1, nil, &mockState{},
+	)
+	tracer.CallEnd(1, nil, errors.New("reverted"))
+	tracer.TxEnd(40000)
+
+	assert.Equal(t, uint64(60000), tracer.GasUsed())
+	assert.Error(t, tracer.Err())
+	assert.Len(t, tracer.AccessList(), 2)
+
+	tracer.Clear()
+
+	assert.Zero(t, tracer.GasUsed())
+	assert.NoError(t, tracer.Err())
+	assert.Equal(t, initial, tracer.AccessList())
+}
+
+func TestAccessListTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewAccessListTracer(nil, nil)
+	state := &mockState{}
+	reason := errors.New("timeout")
+
+	tracer.Cancel(reason)
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(1)}, evm.SLOAD, testContract, 1, nil, state)
+
+	assert.True(t, state.halted)
+	assert.Empty(t, tracer.AccessList())
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, reason)
+}
@@ -0,0 +1,290 @@
+package calltracer
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxLogDataSize is the upper bound of the captured log data.
+// Expanding the memory beyond it costs more gas than any block can hold
+const maxLogDataSize = 1 << 25
+
+// Name is the name the tracer is registered by
+const Name = "callTracer"
+
+func init() {
+	tracer.Register(Name, Factory)
+}
+
+// Factory creates the tracer from its JSON config
+func Factory(rawConfig json.RawMessage) (tracer.Tracer, error) {
+	var config Config
+	if err := tracer.UnmarshalConfig(rawConfig, &config); err != nil {
+		return nil, err
+	}
+
+	return NewCallTracer(config), nil
+}
+
+type Config struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // skip the nested calls
+	WithLog     bool `json:"withLog"`     // capture the logs emitted by every call
+}
+
+// CallLog is a log emitted during a call
+type CallLog struct {
+	Address types.Address `json:"address"`
+	Topics  []types.Hash  `json:"topics"`
+	Data    string        `json:"data"`
+}
+
+// CallFrame is a single call along with its nested calls
+type CallFrame struct {
+	Type    string        `json:"type"`
+	From    types.Address `json:"from"`
+	To      types.Address `json:"to"`
+	Value   string        `json:"value,omitempty"`
+	Gas     string        `json:"gas"`
+	GasUsed string        `json:"gasUsed,omitempty"`
+	Input   string        `json:"input"`
+	Output  string        `json:"output,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Logs    []*CallLog    `json:"logs,omitempty"`
+	Calls   []*CallFrame  `json:"calls,omitempty"`
+
+	parent *CallFrame
+}
+
+// CallTracer records the tree of the calls made during the execution
+type CallTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	root     *CallFrame
+	active   *CallFrame
+	gasLimit uint64
+}
+
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+	}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *CallTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.root = nil
+	t.active = nil
+	t.gasLimit = 0
+}
+
+func (t *CallTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {
+	if t.root == nil {
+		return
+	}
+
+	t.root.GasUsed = hex.EncodeUint64(t.gasLimit - gasLeft)
+}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if depth > 1 && (t.Config.OnlyTopCall || t.active == nil) {
+		return
+	}
+
+	frame := &CallFrame{
+		Type:  callTypeName(callType),
+		From:  from,
+		To:    to,
+		Gas:   hex.EncodeUint64(gas),
+		Input: hex.EncodeToHex(input),
+	}
+
+	// the value of delegate and static calls is inherited, not transferred
+	if value != nil && callType != int(runtime.DelegateCall) && callType != int(runtime.StaticCall) {
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	if depth == 1 {
+		// the top call is reported with the gas limit of the transaction
+		if t.gasLimit > 0 {
+			frame.Gas = hex.EncodeUint64(t.gasLimit)
+		}
+
+		t.root = frame
+		t.active = frame
+
+		return
+	}
+
+	frame.parent = t.active
+	t.active.Calls = append(t.active.Calls, frame)
+	t.active = frame
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	err error,
+) {
+	if t.active == nil || (depth > 1 && t.Config.OnlyTopCall) {
+		return
+	}
+
+	frame := t.active
+
+	if err == nil || errors.Is(err, runtime.ErrExecutionReverted) {
+		if len(output) > 0 {
+			frame.Output = hex.EncodeToHex(output)
+		}
+	}
+
+	if err != nil {
+		frame.Error = err.Error()
+
+		// the logs of a failed call are discarded along with the ones of its nested calls
+		clearLogs(frame)
+	}
+
+	t.active = frame.parent
+}
+
+func clearLogs(frame *CallFrame) {
+	frame.Logs = nil
+
+	for _, call := range frame.Calls {
+		clearLogs(call)
+	}
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if !t.Config.WithLog || t.active == nil || opCode < evm.LOG0 || opCode > evm.LOG4 {
+		return
+	}
+
+	numTopics := opCode - evm.LOG0
+	if sp < 2+numTopics {
+		return
+	}
+
+	offset, size := stack[sp-1], stack[sp-2]
+	if !offset.IsUint64() || !size.IsUint64() || size.Uint64() > maxLogDataSize {
+		return
+	}
+
+	log := &CallLog{
+		Address: contractAddress,
+		Topics:  make([]types.Hash, numTopics),
+		Data:    hex.EncodeToHex(memorySlice(memory, offset.Uint64(), size.Uint64())),
+	}
+
+	for i := 0; i < numTopics; i++ {
+		log.Topics[i] = types.BytesToHash(stack[sp-3-i].Bytes())
+	}
+
+	t.active.Logs = append(t.active.Logs, log)
+}
+
+// memorySlice returns a copy of the given range of the memory,
+// the range beyond the current memory is expanded with zeros
+func memorySlice(memory []byte, offset, size uint64) []byte {
+	res := make([]byte, size)
+
+	if offset < uint64(len(memory)) {
+		copy(res, memory[offset:])
+	}
+
+	return res
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *CallTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return t.root, nil
+}
+
+func callTypeName(callType int) string {
+	switch callType {
+	case int(runtime.Call):
+		return "CALL"
+	case int(runtime.CallCode):
+		return "CALLCODE"
+	case int(runtime.DelegateCall):
+		return "DELEGATECALL"
+	case int(runtime.StaticCall):
+		return "STATICCALL"
+	case evm.CREATE:
+		return "CREATE"
+	case evm.CREATE2:
+		return "CREATE2"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,169 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testContract = types.StringToAddress("2")
+	testCallee   = types.StringToAddress("3")
+	testCreated  = types.StringToAddress("4")
+	testTopic    = types.StringToHash("5")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+// emitLog captures LOG1 of the given data at the current call
+func emitLog(tracer *CallTracer, address types.Address, data []byte) {
+	stack := []*big.Int{
+		new(big.Int).SetBytes(testTopic.Bytes()),
+		big.NewInt(int64(len(data))),
+		big.NewInt(0),
+	}
+
+	tracer.CaptureState(data, stack, evm.LOG1, address, len(stack), nil, &mockState{})
+}
+
+// runTrace simulates a transaction calling a contract which makes
+// a successful static call and a failing CREATE2
+func runTrace(tracer *CallTracer) {
+	tracer.TxStart(100000)
+	tracer.CallStart(1, testFrom, testContract, int(runtime.Call), 79000, big.NewInt(10), []byte{0x1})
+	emitLog(tracer, testContract, []byte{0xa})
+
+	tracer.CallStart(2, testContract, testCallee, int(runtime.StaticCall), 5000, big.NewInt(10), []byte{0x2})
+	emitLog(tracer, testCallee, []byte{0xb})
+	tracer.CallEnd(2, []byte{0x3}, nil)
+
+	tracer.CallStart(2, testContract, testCreated, evm.CREATE2, 6000, big.NewInt(0), []byte{0x4})
+	emitLog(tracer, testCreated, []byte{0xc})
+	tracer.CallEnd(2, []byte{0x5}, runtime.ErrExecutionReverted)
+
+	tracer.CallEnd(1, []byte{0x6}, nil)
+	tracer.TxEnd(40000)
+}
+
+func TestCallTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{WithLog: true})
+	runTrace(tracer)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	root, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Equal(t, "CALL", root.Type)
+	assert.Equal(t, testFrom, root.From)
+	assert.Equal(t, testContract, root.To)
+	assert.Equal(t, "0xa", root.Value)
+	assert.Equal(t, "0x186a0", root.Gas)
+	assert.Equal(t, "0xea60", root.GasUsed)
+	assert.Equal(t, "0x01", root.Input)
+	assert.Equal(t, "0x06", root.Output)
+	assert.Empty(t, root.Error)
+	require.Len(t, root.Logs, 1)
+	assert.Equal(t, &CallLog{Address: testContract, Topics: []types.Hash{testTopic}, Data: "0x0a"}, root.Logs[0])
+
+	require.Len(t, root.Calls, 2)
+
+	static := root.Calls[0]
+	assert.Equal(t, "STATICCALL", static.Type)
+	assert.Empty(t, static.Value)
+	assert.Equal(t, "0x1388", static.Gas)
+	assert.Equal(t, "0x03", static.Output)
+	assert.Len(t, static.Logs, 1)
+
+	create := root.Calls[1]
+	assert.Equal(t, "CREATE2", create.Type)
+	assert.Equal(t, testCreated, create.To)
+	assert.Equal(t, "0x0", create.Value)
+	assert.Equal(t, "0x05", create.Output)
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), create.Error)
+	assert.Empty(t, create.Logs)
+}
+
+func TestCallTracer_OnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true})
+	runTrace(tracer)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	root, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Empty(t, root.Calls)
+	assert.Empty(t, root.Logs)
+	assert.Equal(t, "0x06", root.Output)
+}
+
+func TestCallTracer_FailedCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{WithLog: true})
+
+	tracer.TxStart(50000)
+	tracer.CallStart(1, testFrom, testContract, int(runtime.Call), 29000, big.NewInt(0), nil)
+	emitLog(tracer, testContract, []byte{0xa})
+	tracer.CallStart(2, testContract, testCallee, int(runtime.DelegateCall), 5000, big.NewInt(0), nil)
+	emitLog(tracer, testCallee, []byte{0xb})
+	tracer.CallEnd(2, nil, nil)
+	tracer.CallEnd(1, []byte{0x1}, runtime.ErrOutOfGas)
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	root, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	// output of non-revert errors is omitted and the logs of the whole tree are discarded
+	assert.Empty(t, root.Output)
+	assert.Equal(t, runtime.ErrOutOfGas.Error(), root.Error)
+	assert.Empty(t, root.Logs)
+	require.Len(t, root.Calls, 1)
+	assert.Empty(t, root.Calls[0].Logs)
+}
+
+func TestCallTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+
+	tracer := NewCallTracer(Config{})
+	tracer.Cancel(cancelErr)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testContract, 0, nil, state)
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, cancelErr, err)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
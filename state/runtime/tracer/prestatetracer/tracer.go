@@ -0,0 +1,377 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxInitCodeSize is the upper bound of the init code read from the memory for CREATE2
+const maxInitCodeSize = 1 << 25
+
+// Name is the name the tracer is registered by
+const Name = "prestateTracer"
+
+func init() {
+	tracer.Register(Name, Factory)
+}
+
+// Factory creates the tracer from its JSON config
+func Factory(rawConfig json.RawMessage) (tracer.Tracer, error) {
+	var config Config
+	if err := tracer.UnmarshalConfig(rawConfig, &config); err != nil {
+		return nil, err
+	}
+
+	return NewPrestateTracer(config), nil
+}
+
+type Config struct {
+	DiffMode bool `json:"diffMode"` // return the changes made by the execution
+}
+
+// Account is the state of an account
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// DiffResult is the result of the tracer in diff mode
+type DiffResult struct {
+	Pre  map[types.Address]*Account `json:"pre"`
+	Post map[types.Address]*Account `json:"post"`
+}
+
+type accountState struct {
+	exists  bool
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+// PrestateTracer records the state of the accounts and storage slots
+// touched by the execution before they were modified
+type PrestateTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	host     tracer.RuntimeHost
+	accounts []types.Address
+	pre      map[types.Address]*accountState
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+		pre:        make(map[types.Address]*accountState),
+	}
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *PrestateTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.host = nil
+	t.accounts = nil
+	t.pre = make(map[types.Address]*accountState)
+}
+
+func (t *PrestateTracer) TxPreState(host tracer.RuntimeHost, accounts []types.Address) {
+	t.host = host
+
+	for _, addr := range accounts {
+		t.lookupAccount(addr)
+	}
+}
+
+func (t *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+func (t *PrestateTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *PrestateTracer) CallEnd(
+	depth int,
+	output []byte,
+	err error,
+) {
+}
+
+func (t *PrestateTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	t.host = host
+	t.lookupAccount(contractAddress)
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			t.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			t.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+	case evm.CREATE:
+		t.lookupAccount(crypto.CreateAddress(contractAddress, host.GetNonce(contractAddress)))
+	case evm.CREATE2:
+		if sp < 4 {
+			return
+		}
+
+		offset, size := stack[sp-2], stack[sp-3]
+		if !offset.IsUint64() || !size.IsUint64() || size.Uint64() > maxInitCodeSize {
+			return
+		}
+
+		initCode := memorySlice(memory, offset.Uint64(), size.Uint64())
+		salt := types.BytesToHash(stack[sp-4].Bytes())
+
+		t.lookupAccount(crypto.CreateAddress2(contractAddress, salt, initCode))
+	}
+}
+
+// memorySlice returns a copy of the given range of the memory,
+// the range beyond the current memory is expanded with zeros
+func memorySlice(memory []byte, offset, size uint64) []byte {
+	res := make([]byte, size)
+
+	if offset < uint64(len(memory)) {
+		copy(res, memory[offset:])
+	}
+
+	return res
+}
+
+func (t *PrestateTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+// lookupAccount records the current state of the account if it hasn't been seen yet
+func (t *PrestateTracer) lookupAccount(addr types.Address) {
+	if _, ok := t.pre[addr]; ok || t.host == nil {
+		return
+	}
+
+	t.pre[addr] = t.readAccount(addr)
+	t.accounts = append(t.accounts, addr)
+}
+
+// lookupStorage records the current value of the storage slot if it hasn't been seen yet
+func (t *PrestateTracer) lookupStorage(addr types.Address, key types.Hash) {
+	t.lookupAccount(addr)
+
+	account, ok := t.pre[addr]
+	if !ok {
+		return
+	}
+
+	if _, ok := account.storage[key]; ok {
+		return
+	}
+
+	account.storage[key] = t.host.GetStorage(addr, key)
+}
+
+func (t *PrestateTracer) readAccount(addr types.Address) *accountState {
+	balance := t.host.GetBalance(addr)
+	nonce := t.host.GetNonce(addr)
+	code := t.host.GetCode(addr)
+
+	return &accountState{
+		exists:  nonce != 0 || len(code) != 0 || (balance != nil && balance.Sign() != 0),
+		balance: new(big.Int).Set(balanceOrZero(balance)),
+		nonce:   nonce,
+		code:    code,
+		storage: make(map[types.Hash]types.Hash),
+	}
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	if t.Config.DiffMode {
+		return t.diff(), nil
+	}
+
+	res := make(map[types.Address]*Account, len(t.pre))
+
+	for addr, state := range t.pre {
+		res[addr] = toAccount(state, state.storage)
+	}
+
+	return res, nil
+}
+
+// diff compares the recorded state against the current one and
+// returns only the accounts and storage slots modified by the execution
+func (t *PrestateTracer) diff() *DiffResult {
+	res := &DiffResult{
+		Pre:  make(map[types.Address]*Account),
+		Post: make(map[types.Address]*Account),
+	}
+
+	if t.host == nil {
+		return res
+	}
+
+	for _, addr := range t.accounts {
+		pre := t.pre[addr]
+		post := t.readAccount(addr)
+
+		var (
+			preStorage  = make(map[types.Hash]types.Hash)
+			postStorage = make(map[types.Hash]types.Hash)
+		)
+
+		for key, val := range pre.storage {
+			newVal := t.host.GetStorage(addr, key)
+			if val == newVal {
+				continue
+			}
+
+			if val != (types.Hash{}) {
+				preStorage[key] = val
+			}
+
+			if newVal != (types.Hash{}) {
+				postStorage[key] = newVal
+			}
+		}
+
+		modified := pre.balance.Cmp(post.balance) != 0 ||
+			pre.nonce != post.nonce ||
+			!bytes.Equal(pre.code, post.code) ||
+			len(preStorage) != 0 ||
+			len(postStorage) != 0
+
+		if !modified {
+			continue
+		}
+
+		if pre.exists {
+			res.Pre[addr] = toAccount(pre, preStorage)
+		}
+
+		if !post.exists {
+			continue
+		}
+
+		// only the modified fields are reported in the post state
+		account := &Account{}
+
+		if len(postStorage) != 0 {
+			account.Storage = postStorage
+		}
+
+		if pre.balance.Cmp(post.balance) != 0 {
+			account.Balance = hex.EncodeBig(post.balance)
+		}
+
+		if pre.nonce != post.nonce {
+			account.Nonce = post.nonce
+		}
+
+		if !bytes.Equal(pre.code, post.code) {
+			account.Code = hex.EncodeToHex(post.code)
+		}
+
+		res.Post[addr] = account
+	}
+
+	return res
+}
+
+func toAccount(state *accountState, storage map[types.Hash]types.Hash) *Account {
+	account := &Account{
+		Balance: hex.EncodeBig(state.balance),
+		Nonce:   state.nonce,
+	}
+
+	if len(state.code) != 0 {
+		account.Code = hex.EncodeToHex(state.code)
+	}
+
+	if len(storage) != 0 {
+		account.Storage = make(map[types.Hash]types.Hash, len(storage))
+
+		for key, val := range storage {
+			account.Storage[key] = val
+		}
+	}
+
+	return account
+}
+
+func balanceOrZero(balance *big.Int) *big.Int {
+	if balance == nil {
+		return big.NewInt(0)
+	}
+
+	return balance
+}
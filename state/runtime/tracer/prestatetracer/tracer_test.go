@@ -0,0 +1,220 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testContract = types.StringToAddress("2")
+	testCallee   = types.StringToAddress("3")
+	testCoinbase = types.StringToAddress("4")
+	testSlot1    = types.StringToHash("5")
+	testSlot2    = types.StringToHash("6")
+)
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func (m *mockHost) account(addr types.Address) *mockAccount {
+	if acc, ok := m.accounts[addr]; ok {
+		return acc
+	}
+
+	return &mockAccount{balance: big.NewInt(0)}
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.account(addr).storage[key]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return m.account(addr).balance
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.account(addr).nonce
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.account(addr).code
+}
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func newTestHost() *mockHost {
+	return &mockHost{
+		accounts: map[types.Address]*mockAccount{
+			testFrom: {
+				balance: big.NewInt(1000),
+				nonce:   1,
+			},
+			testContract: {
+				balance: big.NewInt(0),
+				code:    []byte{0x1},
+				storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("10"),
+					testSlot2: types.StringToHash("11"),
+				},
+			},
+		},
+	}
+}
+
+// runTrace simulates a transaction which reads and writes the storage
+// of the contract, calls another account and creates a new contract
+func runTrace(t *testing.T, tracer *PrestateTracer, host *mockHost) types.Address {
+	t.Helper()
+
+	tracer.TxPreState(host, []types.Address{testFrom, testContract, testCoinbase})
+
+	slot := func(key types.Hash) *big.Int {
+		return new(big.Int).SetBytes(key.Bytes())
+	}
+
+	state := &mockState{}
+	tracer.CaptureState(nil, []*big.Int{slot(testSlot1)}, evm.SLOAD, testContract, 1, host, state)
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(1), slot(testSlot2)}, evm.SSTORE, testContract, 2, host, state)
+
+	callee := new(big.Int).SetBytes(testCallee.Bytes())
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(0), callee, big.NewInt(100)}, evm.CALL, testContract, 3, host, state)
+
+	created := crypto.CreateAddress(testContract, 0)
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}, evm.CREATE, testContract, 3, host, state)
+
+	assert.False(t, state.halted)
+
+	// apply the changes of the execution
+	host.accounts[testFrom].balance = big.NewInt(900)
+	host.accounts[testFrom].nonce = 2
+	host.accounts[testContract].storage[testSlot2] = types.StringToHash("12")
+	host.accounts[testCallee] = &mockAccount{balance: big.NewInt(100)}
+	host.accounts[created] = &mockAccount{balance: big.NewInt(0), nonce: 1, code: []byte{0x2}}
+
+	return created
+}
+
+func TestPrestateTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+	created := runTrace(t, tracer, newTestHost())
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	expected := map[types.Address]*Account{
+		testFrom: {
+			Balance: "0x3e8",
+			Nonce:   1,
+		},
+		testContract: {
+			Balance: "0x0",
+			Code:    "0x01",
+			Storage: map[types.Hash]types.Hash{
+				testSlot1: types.StringToHash("10"),
+				testSlot2: types.StringToHash("11"),
+			},
+		},
+		testCoinbase: {Balance: "0x0"},
+		testCallee:   {Balance: "0x0"},
+		created:      {Balance: "0x0"},
+	}
+
+	assert.Equal(t, expected, res)
+}
+
+func TestPrestateTracer_DiffMode(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+	created := runTrace(t, tracer, newTestHost())
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	expected := &DiffResult{
+		Pre: map[types.Address]*Account{
+			testFrom: {
+				Balance: "0x3e8",
+				Nonce:   1,
+			},
+			testContract: {
+				Balance: "0x0",
+				Code:    "0x01",
+				Storage: map[types.Hash]types.Hash{
+					testSlot2: types.StringToHash("11"),
+				},
+			},
+		},
+		Post: map[types.Address]*Account{
+			testFrom: {
+				Balance: "0x384",
+				Nonce:   2,
+			},
+			testContract: {
+				Storage: map[types.Hash]types.Hash{
+					testSlot2: types.StringToHash("12"),
+				},
+			},
+			testCallee: {
+				Balance: "0x64",
+			},
+			created: {
+				Nonce: 1,
+				Code:  "0x02",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, res)
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+
+	tracer := NewPrestateTracer(Config{})
+	tracer.Cancel(cancelErr)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testContract, 0, newTestHost(), state)
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, cancelErr, err)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
@@ -0,0 +1,87 @@
+package tracer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownTracer is returned when the requested tracer isn't registered
+var ErrUnknownTracer = errors.New("unknown tracer")
+
+// Factory creates a new instance of a tracer from its raw JSON config.
+// The config is empty when the caller doesn't provide one
+type Factory func(config json.RawMessage) (Tracer, error)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Factory{}
+)
+
+// Register makes a tracer available by the given name to the debug endpoints.
+// It's meant to be called from the init function of the package implementing
+// the tracer, which then needs to be imported by the binary.
+// Register panics if the name is empty or already taken
+func Register(name string, factory Factory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if name == "" || factory == nil {
+		panic("tracer: invalid tracer registration")
+	}
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("tracer: tracer %s registered twice", name))
+	}
+
+	registry[name] = factory
+}
+
+// New creates the tracer registered by the given name
+func New(name string, config json.RawMessage) (Tracer, error) {
+	registryLock.RLock()
+	factory, ok := registry[name]
+	registryLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracer, name)
+	}
+
+	tracer, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracer %s: %w", name, err)
+	}
+
+	return tracer, nil
+}
+
+// Names returns the sorted names of the registered tracers
+func Names() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// UnmarshalConfig decodes the raw JSON config of a tracer into the given value,
+// the value is left untouched if the config is empty
+func UnmarshalConfig(raw json.RawMessage, config interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, config); err != nil {
+		return fmt.Errorf("invalid tracer config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package tracer
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Limit uint64 `json:"limit"`
+}
+
+type testTracer struct {
+	config testConfig
+}
+
+func (t *testTracer) Cancel(error)                    {}
+func (t *testTracer) Clear()                          {}
+func (t *testTracer) GetResult() (interface{}, error) { return t.config, nil }
+func (t *testTracer) TxStart(uint64)                  {}
+func (t *testTracer) TxEnd(uint64)                    {}
+
+func (t *testTracer) CallStart(int, types.Address, types.Address, int, uint64, *big.Int, []byte) {}
+
+func (t *testTracer) CallEnd(int, []byte, error) {}
+
+func (t *testTracer) CaptureState([]byte, []*big.Int, int, types.Address, int, RuntimeHost, VMState) {
+}
+
+func (t *testTracer) ExecuteState(types.Address, uint64, string, uint64, uint64, []byte, int, error, RuntimeHost) {
+}
+
+func testFactory(raw json.RawMessage) (Tracer, error) {
+	tracer := &testTracer{config: testConfig{Limit: 10}}
+
+	if err := UnmarshalConfig(raw, &tracer.config); err != nil {
+		return nil, err
+	}
+
+	return tracer, nil
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	Register("registryTestTracer", testFactory)
+
+	assert.Contains(t, Names(), "registryTestTracer")
+
+	assert.Panics(t, func() {
+		Register("registryTestTracer", testFactory)
+	})
+
+	assert.Panics(t, func() {
+		Register("", testFactory)
+	})
+
+	// the default config is kept if the config is empty
+	for _, raw := range []json.RawMessage{nil, json.RawMessage("null")} {
+		tracer, err := New("registryTestTracer", raw)
+		require.NoError(t, err)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+		assert.Equal(t, testConfig{Limit: 10}, res)
+	}
+
+	tracer, err := New("registryTestTracer", json.RawMessage(`{"limit": 5}`))
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	assert.Equal(t, testConfig{Limit: 5}, res)
+
+	_, err = New("registryTestTracer", json.RawMessage(`{"limit": "5"}`))
+	assert.Error(t, err)
+
+	_, err = New("missingTracer", nil)
+	assert.ErrorIs(t, err, ErrUnknownTracer)
+}
@@ -43,6 +43,18 @@ func (m *mockHost) GetStorage(a types.Address, h types.Hash) types.Hash {
 	return m.getStorageFunc(a, h)
 }
 
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
 func TestStructLogErrorString(t *testing.T) {
 	t.Parallel()
 
@@ -12,6 +12,12 @@ type RuntimeHost interface {
 	GetRefund() uint64
 	// GetStorage access the storage slot at the given address and slot hash
 	GetStorage(types.Address, types.Hash) types.Hash
+	// GetBalance returns the balance of the given address
+	GetBalance(types.Address) *big.Int
+	// GetNonce returns the nonce of the given address
+	GetNonce(types.Address) uint64
+	// GetCode returns the code of the given address
+	GetCode(types.Address) []byte
 }
 
 type VMState interface {
@@ -68,3 +74,11 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// TxStateTracer is implemented by the tracers which need to read the state
+// of the accounts touched by a transaction before the transaction modifies it
+type TxStateTracer interface {
+	// TxPreState is called before the transaction is applied, along with the accounts
+	// the transaction touches regardless of its execution (sender, recipient and coinbase)
+	TxPreState(host RuntimeHost, accounts []types.Address)
+}
@@ -21,6 +21,24 @@ type Snapshot interface {
 	readSnapshot
 
 	Commit(objs []*Object) (Snapshot, []byte)
+
+	// GetProof returns the merkle proof of the account and of its storage keys
+	GetProof(addr types.Address, storageKeys []types.Hash) (*AccountProof, error)
+}
+
+// StorageProof is the merkle proof of a storage slot of an account
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
+// AccountProof is the merkle proof of an account along with the proofs of its storage slots.
+// Account is nil if the account doesn't exist, in which case the proof proves its absence
+type AccountProof struct {
+	Account      *Account
+	Proof        [][]byte
+	StorageProof []*StorageProof
 }
 
 // Account is the account reference in the ethereum state
@@ -1,9 +1,11 @@
 package state
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -159,3 +161,92 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	// 33 bytes of non-zero initcode occupy two words
+	msg := &types.Transaction{
+		Input: bytes.Repeat([]byte{0x1}, 33),
+	}
+
+	preShanghai, err := TransactionGasCost(msg, true, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, TxGasContractCreation+33*16, preShanghai)
+
+	shanghai, err := TransactionGasCost(msg, true, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, preShanghai+2*runtime.InitCodeWordGas, shanghai)
+
+	// initcode is not metered for regular calls
+	msg.To = &addr2
+
+	call, err := TransactionGasCost(msg, true, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, TxGas+33*16, call)
+}
+
+func TestCheckAndProcessTx_MaxInitCodeSize(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Nonce:   0,
+			Balance: 1000000,
+		},
+	})
+	transition.config = chain.ForksInTime{Shanghai: true}
+
+	msg := &types.Transaction{
+		From:     addr1,
+		Gas:      100000,
+		GasPrice: big.NewInt(1),
+		Input:    make([]byte, runtime.MaxInitCodeSize+1),
+	}
+
+	err := checkAndProcessTx(msg, transition)
+
+	var appErr *TransitionApplicationError
+
+	// the creation transaction is invalid, it is not included and does not burn the gas
+	assert.ErrorAs(t, err, &appErr)
+	assert.ErrorIs(t, appErr.Err, runtime.ErrMaxInitCodeSizeExceeded)
+	assert.False(t, appErr.IsRecoverable)
+	assert.Equal(t, big.NewInt(1000000), transition.GetBalance(addr1))
+
+	// the initcode at the size limit is accepted
+	msg.Input = msg.Input[:runtime.MaxInitCodeSize]
+
+	assert.NoError(t, checkAndProcessTx(msg, transition))
+}
+
+func TestSelfdestruct_EIP6780(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Nonce:   1,
+			Balance: 1000,
+		},
+		addr2: {
+			Nonce:   1,
+			Balance: 0,
+		},
+	})
+	transition.config = chain.ForksInTime{Cancun: true}
+
+	// the account created in an earlier transaction only sends its balance
+	transition.Selfdestruct(addr1, addr2)
+
+	assert.False(t, transition.state.HasSuicided(addr1))
+	assert.Zero(t, transition.GetBalance(addr1).Sign())
+	assert.Equal(t, big.NewInt(1000), transition.GetBalance(addr2))
+	assert.Zero(t, transition.GetRefund())
+
+	// the account created in the same transaction is destroyed
+	transition.state.MarkCreatedInTx(addr2)
+	transition.Selfdestruct(addr2, addr1)
+
+	assert.True(t, transition.state.HasSuicided(addr2))
+	assert.Equal(t, big.NewInt(1000), transition.GetBalance(addr1))
+}
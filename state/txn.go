@@ -26,6 +26,15 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the access list entries in the trie
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// transientStorageIndex is the prefix of the transient storage entries in the trie
+	transientStorageIndex = types.BytesToHash([]byte{5}).Bytes()
+
+	// createdAccountIndex is the prefix of the accounts created by the current transaction
+	createdAccountIndex = types.BytesToHash([]byte{6}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -268,13 +277,19 @@ func (txn *Txn) SetStorage(
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -443,6 +458,122 @@ func (txn *Txn) SubRefund(gas uint64) {
 	txn.txn.Insert(refundIndex, refund)
 }
 
+// Access list
+
+// accessListKey returns the trie key of the access list entry
+// for the given address or, if the slot is provided, for the address storage slot
+func accessListKey(addr types.Address, slot *types.Hash) []byte {
+	key := make([]byte, 0, len(accessListIndex)+types.AddressLength+types.HashLength)
+	key = append(key, accessListIndex...)
+	key = append(key, addr.Bytes()...)
+
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+
+	return key
+}
+
+// AddAddressToAccessList adds the address to the access list of the current transaction
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	if !txn.ContainsAccessListAddress(addr) {
+		txn.txn.Insert(accessListKey(addr, nil), struct{}{})
+	}
+}
+
+// AddSlotToAccessList adds the storage slot, along with its address,
+// to the access list of the current transaction
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.AddAddressToAccessList(addr)
+
+	if _, slotOk := txn.ContainsAccessListSlot(addr, slot); !slotOk {
+		txn.txn.Insert(accessListKey(addr, &slot), struct{}{})
+	}
+}
+
+// ContainsAccessListAddress checks if the address is in the access list
+func (txn *Txn) ContainsAccessListAddress(addr types.Address) bool {
+	_, ok := txn.txn.Get(accessListKey(addr, nil))
+
+	return ok
+}
+
+// ContainsAccessListSlot checks if the address and the storage slot are in the access list
+func (txn *Txn) ContainsAccessListSlot(addr types.Address, slot types.Hash) (bool, bool) {
+	if !txn.ContainsAccessListAddress(addr) {
+		return false, false
+	}
+
+	_, slotOk := txn.txn.Get(accessListKey(addr, &slot))
+
+	return true, slotOk
+}
+
+// ClearTxScope removes the entries that live only for the duration of a single transaction
+// (the access list, the transient storage and the accounts created by the transaction)
+func (txn *Txn) ClearTxScope() {
+	txn.txn.DeletePrefix(accessListIndex)
+	txn.txn.DeletePrefix(transientStorageIndex)
+	txn.txn.DeletePrefix(createdAccountIndex)
+}
+
+// Transient storage
+
+// transientStorageKey returns the trie key of the transient storage slot of the given address
+func transientStorageKey(addr types.Address, key types.Hash) []byte {
+	k := make([]byte, 0, len(transientStorageIndex)+types.AddressLength+types.HashLength)
+	k = append(k, transientStorageIndex...)
+	k = append(k, addr.Bytes()...)
+	k = append(k, key.Bytes()...)
+
+	return k
+}
+
+// GetTransientState returns the value of the transient storage slot (EIP-1153)
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, ok := txn.txn.Get(transientStorageKey(addr, key))
+	if !ok {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// SetTransientState sets the value of the transient storage slot (EIP-1153)
+func (txn *Txn) SetTransientState(addr types.Address, key, value types.Hash) {
+	if value == types.ZeroHash {
+		txn.txn.Delete(transientStorageKey(addr, key))
+
+		return
+	}
+
+	txn.txn.Insert(transientStorageKey(addr, key), value)
+}
+
+// Created accounts
+
+// createdAccountKey returns the trie key of the created account entry
+func createdAccountKey(addr types.Address) []byte {
+	key := make([]byte, 0, len(createdAccountIndex)+types.AddressLength)
+	key = append(key, createdAccountIndex...)
+	key = append(key, addr.Bytes()...)
+
+	return key
+}
+
+// MarkCreatedInTx marks the account as created by the current transaction
+func (txn *Txn) MarkCreatedInTx(addr types.Address) {
+	txn.txn.Insert(createdAccountKey(addr), struct{}{})
+}
+
+// CreatedInTx checks if the account was created by the current transaction
+func (txn *Txn) CreatedInTx(addr types.Address) bool {
+	_, ok := txn.txn.Get(createdAccountKey(addr))
+
+	return ok
+}
+
 func (txn *Txn) Logs() []*types.Log {
 	data, exists := txn.txn.Get(logIndex)
 	if !exists {
@@ -571,6 +702,8 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) {
 
 	// delete refunds
 	txn.txn.Delete(refundIndex)
+
+	txn.ClearTxScope()
 }
 
 func (txn *Txn) Commit(deleteEmptyObjects bool) []*Object {
@@ -49,6 +49,10 @@ func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
 	return nil, nil
 }
 
+func (m *mockSnapshot) GetProof(addr types.Address, storageKeys []types.Hash) (*AccountProof, error) {
+	return nil, nil
+}
+
 func newStateWithPreState(preState map[types.Address]*PreState) Snapshot {
 	return &mockSnapshot{state: preState}
 }
@@ -70,3 +74,49 @@ func TestSnapshotUpdateData(t *testing.T) {
 	txn.RevertToSnapshot(ss)
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestAccessList_RevertAndClean(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.AddAddressToAccessList(addr1)
+	assert.True(t, txn.ContainsAccessListAddress(addr1))
+
+	ss := txn.Snapshot()
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addrOk, slotOk := txn.ContainsAccessListSlot(addr2, hash1)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+
+	txn.RevertToSnapshot(ss)
+
+	addrOk, slotOk = txn.ContainsAccessListSlot(addr2, hash1)
+	assert.False(t, addrOk)
+	assert.False(t, slotOk)
+	assert.True(t, txn.ContainsAccessListAddress(addr1))
+
+	txn.CleanDeleteObjects(true)
+	assert.False(t, txn.ContainsAccessListAddress(addr1))
+}
+
+func TestTransientStorage_RevertAndClean(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash1, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+
+	ss := txn.Snapshot()
+	txn.SetTransientState(addr1, hash1, hash1)
+	txn.SetTransientState(addr2, hash1, hash2)
+
+	txn.RevertToSnapshot(ss)
+
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr2, hash1))
+
+	// transient storage is not persisted to the regular storage, which keeps the prestate value
+	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
+
+	txn.CleanDeleteObjects(true)
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr1, hash1))
+}
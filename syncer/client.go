@@ -289,6 +289,11 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
+// PenalizePeer penalizes the peer for serving invalid data
+func (m *syncPeerClient) PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string) {
+	m.network.PenalizePeer(peerID, penalty, reason)
+}
+
 // GetBlocks returns a stream of blocks from given height to peer's latest
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -92,6 +93,8 @@ type mockSyncPeerClient struct {
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+
+	penalizedPeers []peer.ID
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -132,6 +135,10 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) PenalizePeer(peerID peer.ID, _ network.Penalty, _ string) {
+	m.penalizedPeers = append(m.penalizedPeers, peerID)
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -702,6 +709,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
 		err                   error
+		penalized             bool
 	}{
 		{
 			name:            "should sync blocks to the latest successfully",
@@ -769,6 +777,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
 			err:                   errInvalidBlock,
+			penalized:             true,
 		},
 		{
 			name:            "should return error if block insertion is failed",
@@ -827,6 +836,10 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			var (
 				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
 
+				syncPeerClient = &mockSyncPeerClient{
+					getBlocksHandler: test.getBlocksHandler,
+				}
+
 				syncer = NewTestSyncer(
 					nil,
 					&mockBlockchain{
@@ -843,9 +856,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 						},
 					},
 					test.blockTimeout,
-					&mockSyncPeerClient{
-						getBlocksHandler: test.getBlocksHandler,
-					},
+					syncPeerClient,
 					&mockProgression{},
 				)
 			)
@@ -856,6 +867,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+			assert.Equal(t, test.penalized, len(syncPeerClient.penalizedPeers) != 0)
 		})
 	}
 }
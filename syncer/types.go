@@ -53,6 +53,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// PenalizePeer increases the penalty score of the misbehaving peer
+	PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string)
 }
 
 type Syncer interface {
@@ -103,6 +105,8 @@ type SyncPeerClient interface {
 	GetPeerConnectionUpdateEventCh() <-chan *event.PeerEvent
 	// CloseStream close a stream
 	CloseStream(peerID peer.ID) error
+	// PenalizePeer penalizes the peer for serving invalid data
+	PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string)
 	// DisablePublishingPeerStatus disables publishing status in syncer topic
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -15,6 +16,8 @@ type accountsMap struct {
 	count uint64
 
 	maxEnqueuedLimit uint64
+
+	maxPromotedLimit uint64
 }
 
 // Intializes an account for the given address.
@@ -23,6 +26,7 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 		enqueued:    newAccountQueue(),
 		promoted:    newAccountQueue(),
 		maxEnqueued: m.maxEnqueuedLimit,
+		maxPromoted: m.maxPromotedLimit,
 		nextNonce:   nonce,
 	})
 	newAccount := a.(*account) //nolint:forcetypeassert
@@ -156,6 +160,9 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	// maximum number of slots the promoted transactions can occupy, 0 if unlimited
+	maxPromoted uint64
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -198,7 +205,18 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	prunedPromoted = a.promoted.prune(nonce)
 
 	if nonce <= a.getNonce() {
-		// only the promoted queue needed pruning
+		// only the promoted queue needed pruning,
+		// but the promotions held back by the promoted slots limit
+		// can continue if some slots were freed
+		if len(prunedPromoted) != 0 && a.maxPromoted != 0 {
+			a.enqueued.lock(false)
+			defer a.enqueued.unlock()
+
+			if first := a.enqueued.peek(); first != nil && first.Nonce == a.getNonce() {
+				promoteCh <- promoteRequest{account: first.From}
+			}
+		}
+
 		return
 	}
 
@@ -227,6 +245,27 @@ func (a *account) enqueue(tx *types.Transaction) error {
 	a.enqueued.lock(true)
 	defer a.enqueued.unlock()
 
+	if err := a.checkEnqueue(tx); err != nil {
+		return err
+	}
+
+	// enqueue tx
+	a.enqueued.push(tx)
+
+	return nil
+}
+
+// enqueueable checks if the transaction would be accepted by enqueue, without pushing it
+func (a *account) enqueueable(tx *types.Transaction) error {
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	return a.checkEnqueue(tx)
+}
+
+// checkEnqueue checks the enqueued limit and the nonce of the transaction.
+// Assumes the lock of the enqueued queue is held
+func (a *account) checkEnqueue(tx *types.Transaction) error {
 	if a.enqueued.length() == a.maxEnqueued {
 		return ErrMaxEnqueuedLimitReached
 	}
@@ -236,12 +275,168 @@ func (a *account) enqueue(tx *types.Transaction) error {
 		return ErrNonceTooLow
 	}
 
-	// enqueue tx
-	a.enqueued.push(tx)
-
 	return nil
 }
 
+// promotedLimitReached checks if the transaction would be promoted right away
+// while the promoted transactions of the account occupy all the slots they are allowed to.
+// The transactions with a future nonce are only enqueued, promote() holds them back
+// until there is room. The transactions replacing the queued ones don't need any more slots,
+// so they are never limited
+func (a *account) promotedLimitReached(tx *types.Transaction) bool {
+	if a.maxPromoted == 0 || tx.Nonce != a.getNonce() {
+		return false
+	}
+
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	if a.promoted.get(tx.Nonce) != nil || a.enqueued.get(tx.Nonce) != nil {
+		return false
+	}
+
+	return slotsRequired(a.promoted.queue...) >= a.maxPromoted
+}
+
+// replaceable returns the queued transaction the given one would replace,
+// without swapping them. The replacement has to be priced higher by at least priceBump percents.
+// Returns nil if the nonce isn't queued
+func (a *account) replaceable(tx *types.Transaction, priceBump uint64) (*types.Transaction, error) {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		old := queue.get(tx.Nonce)
+		if old == nil {
+			continue
+		}
+
+		if !isPriceBumped(old, tx, priceBump) {
+			return nil, ErrReplacementUnderpriced
+		}
+
+		return old, nil
+	}
+
+	return nil, nil
+}
+
+// replace swaps the queued transaction having the same nonce as the given one.
+// The replacement has to be priced higher by at least priceBump percents.
+// Returns the replaced transaction, or nil if the nonce isn't queued,
+// and whether the replaced transaction was promoted
+func (a *account) replace(tx *types.Transaction, priceBump uint64) (*types.Transaction, bool, error) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		old := queue.get(tx.Nonce)
+		if old == nil {
+			continue
+		}
+
+		if !isPriceBumped(old, tx, priceBump) {
+			return nil, false, ErrReplacementUnderpriced
+		}
+
+		queue.replace(old, tx)
+
+		return old, queue == a.promoted, nil
+	}
+
+	return nil, false, nil
+}
+
+// evict removes the given transaction from the account.
+// The promoted transactions with higher nonces aren't executable without it,
+// so they are demoted back to the enqueued queue and the nonce is rolled back.
+// Returns false if the transaction isn't queued anymore
+func (a *account) evict(tx *types.Transaction) (wasPromoted bool, demoted []*types.Transaction, ok bool) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	if a.enqueued.remove(tx) {
+		return false, nil, true
+	}
+
+	if !a.promoted.remove(tx) {
+		return false, nil, false
+	}
+
+	for _, promoted := range a.promoted.queue {
+		if promoted.Nonce > tx.Nonce {
+			demoted = append(demoted, promoted)
+		}
+	}
+
+	for _, promoted := range demoted {
+		a.promoted.remove(promoted)
+		a.enqueued.push(promoted)
+	}
+
+	a.setNonce(tx.Nonce)
+
+	return true, demoted, true
+}
+
+// isPriceBumped checks if both the fee cap and the tip of the new transaction
+// are higher than the ones of the old transaction by at least priceBump percents
+func isPriceBumped(old, tx *types.Transaction, priceBump uint64) bool {
+	oldFeeCap, oldTip := txFees(old)
+	newFeeCap, newTip := txFees(tx)
+
+	if newFeeCap.Cmp(oldFeeCap) <= 0 || newTip.Cmp(oldTip) <= 0 {
+		return false
+	}
+
+	bump := new(big.Int).SetUint64(100 + priceBump)
+	hundred := big.NewInt(100)
+
+	// threshold = old * (100 + priceBump) / 100
+	feeCapThreshold := new(big.Int).Div(new(big.Int).Mul(oldFeeCap, bump), hundred)
+	tipThreshold := new(big.Int).Div(new(big.Int).Mul(oldTip, bump), hundred)
+
+	return newFeeCap.Cmp(feeCapThreshold) >= 0 && newTip.Cmp(tipThreshold) >= 0
+}
+
+// txFees returns the fee cap and the tip of the transaction,
+// both of them are the gas price for non dynamic fee transactions
+func txFees(tx *types.Transaction) (*big.Int, *big.Int) {
+	if tx.Type == types.DynamicFeeTx {
+		return bigOrZero(tx.GasFeeCap), bigOrZero(tx.GasTipCap)
+	}
+
+	return bigOrZero(tx.GasPrice), bigOrZero(tx.GasPrice)
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+
+	return v
+}
+
 // Promote moves eligible transactions from enqueued to promoted.
 //
 // Eligible transactions are all sequential in order of nonce
@@ -264,6 +459,7 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 	}
 
 	nextNonce := a.enqueued.peek().Nonce
+	promotedSlots := slotsRequired(a.promoted.queue...)
 
 	// move all promotable txs (enqueued txs that are sequential in nonce)
 	// to the account's promoted queue
@@ -273,6 +469,13 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 			break
 		}
 
+		// stop at the promoted slots limit,
+		// the rest is promoted once the promoted txs get executed
+		slots := slotsRequired(tx)
+		if a.maxPromoted != 0 && promotedSlots != 0 && promotedSlots+slots > a.maxPromoted {
+			break
+		}
+
 		// pop from enqueued
 		tx = a.enqueued.pop()
 
@@ -281,6 +484,7 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 
 		// update counters
 		nextNonce = tx.Nonce + 1
+		promotedSlots += slots
 
 		// prune the transactions with lower nonce
 		pruned = append(pruned, a.enqueued.prune(nextNonce)...)
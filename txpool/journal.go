@@ -0,0 +1,227 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// journalRecordLimit is the maximum size of a single journal record,
+// anything bigger is considered a corrupted journal
+const journalRecordLimit = 4 * txMaxSize
+
+var errJournalCorrupted = errors.New("corrupted txpool journal")
+
+// journal is an append-only file of the local transactions.
+// Each record consists of the size of the transaction (big endian uint32)
+// followed by the RLP encoded transaction.
+// The journal is replayed into the pool on startup
+// and rotated periodically to drop the transactions which left the pool.
+type journal struct {
+	path string
+
+	lock sync.Mutex
+
+	// writer is nil until the journal gets loaded and rotated
+	writer *os.File
+
+	// all transactions the journal holds
+	txs map[types.Hash]*types.Transaction
+}
+
+func newJournal(path string) *journal {
+	return &journal{
+		path: path,
+		txs:  make(map[types.Hash]*types.Transaction),
+	}
+}
+
+// load reads the transactions from the journal and passes them to add.
+// Returns the number of the loaded transactions and the number of
+// the transactions add rejected
+func (j *journal) load(add func(*types.Transaction) error) (loaded, dropped int, err error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		tx, err := readJournalRecord(reader)
+		if errors.Is(err, io.EOF) {
+			return loaded, dropped, nil
+		} else if err != nil {
+			// keep what was loaded, the tail of the journal
+			// is lost if the node crashed while writing it
+			return loaded, dropped, err
+		}
+
+		loaded++
+
+		if err := add(tx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert adds the transaction to the journal.
+// The transaction is only written to the file if the journal has been rotated
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.txs[tx.Hash] = tx
+
+	if j.writer == nil {
+		return nil
+	}
+
+	return writeJournalRecord(j.writer, tx)
+}
+
+// rotate regenerates the journal from the transactions keep accepts,
+// the rest of the transactions is dropped from the journal.
+// Returns the number of the kept transactions
+func (j *journal) rotate(keep func(*types.Transaction) bool) (int, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return 0, err
+		}
+
+		j.writer = nil
+	}
+
+	txs := make([]*types.Transaction, 0, len(j.txs))
+
+	for hash, tx := range j.txs {
+		if !keep(tx) {
+			delete(j.txs, hash)
+
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	// keep the transactions ordered by nonce
+	// so they are replayed in the order they can be promoted
+	sort.Slice(txs, func(i, k int) bool {
+		if txs[i].From != txs[k].From {
+			return txs[i].From.String() < txs[k].From.String()
+		}
+
+		return txs[i].Nonce < txs[k].Nonce
+	})
+
+	tmpPath := j.path + ".new"
+
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+
+	writer := bufio.NewWriter(tmp)
+
+	for _, tx := range txs {
+		if err := writeJournalRecord(writer, tx); err != nil {
+			tmp.Close()
+
+			return 0, err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+
+		return 0, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return 0, err
+	}
+
+	if j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+		return 0, err
+	}
+
+	return len(txs), nil
+}
+
+// close closes the journal file
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+func writeJournalRecord(w io.Writer, tx *types.Transaction) error {
+	raw := tx.MarshalRLP()
+
+	var size [4]byte
+
+	binary.BigEndian.PutUint32(size[:], uint32(len(raw)))
+
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(raw)
+
+	return err
+}
+
+func readJournalRecord(r io.Reader) (*types.Transaction, error) {
+	var size [4]byte
+
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errJournalCorrupted
+		}
+
+		return nil, err
+	}
+
+	recordSize := binary.BigEndian.Uint32(size[:])
+	if recordSize == 0 || recordSize > journalRecordLimit {
+		return nil, errJournalCorrupted
+	}
+
+	raw := make([]byte, recordSize)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, errJournalCorrupted
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", errJournalCorrupted, err)
+	}
+
+	return tx, nil
+}
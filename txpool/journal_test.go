@@ -0,0 +1,189 @@
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadJournalTxs returns all transactions stored in the journal file
+func loadJournalTxs(t *testing.T, path string) []*types.Transaction {
+	t.Helper()
+
+	txs := []*types.Transaction{}
+
+	_, _, err := newJournal(path).load(func(tx *types.Transaction) error {
+		tx.ComputeHash()
+		txs = append(txs, tx)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return txs
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+
+	txs := make([]*types.Transaction, 3)
+	for i := range txs {
+		txs[i] = newTx(types.ZeroAddress, uint64(i), 1)
+		txs[i].ComputeHash()
+	}
+
+	j := newJournal(path)
+
+	// missing journal is empty
+	loaded, dropped, err := j.load(func(*types.Transaction) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, loaded)
+	assert.Equal(t, 0, dropped)
+
+	// transactions aren't written before the first rotation
+	require.NoError(t, j.insert(txs[0]))
+	assert.NoFileExists(t, path)
+
+	kept, err := j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+	assert.Equal(t, 1, kept)
+
+	// transactions are appended after the rotation
+	require.NoError(t, j.insert(txs[1]))
+	require.NoError(t, j.insert(txs[2]))
+
+	journaled := loadJournalTxs(t, path)
+	require.Len(t, journaled, 3)
+
+	for i, tx := range journaled {
+		assert.Equal(t, txs[i].Hash, tx.Hash)
+	}
+
+	// rotation drops the transactions which aren't kept
+	kept, err = j.rotate(func(tx *types.Transaction) bool {
+		return tx.Hash != txs[1].Hash
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, kept)
+
+	require.NoError(t, j.close())
+
+	journaled = loadJournalTxs(t, path)
+	require.Len(t, journaled, 2)
+	assert.Equal(t, txs[0].Hash, journaled[0].Hash)
+	assert.Equal(t, txs[2].Hash, journaled[1].Hash)
+
+	// rejected transactions are counted as dropped
+	loaded, dropped, err = newJournal(path).load(func(tx *types.Transaction) error {
+		return ErrNonceTooLow
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+	assert.Equal(t, 2, dropped)
+}
+
+func TestJournal_CorruptedTail(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+
+	tx := newTx(types.ZeroAddress, 0, 1)
+	tx.ComputeHash()
+
+	j := newJournal(path)
+	require.NoError(t, j.insert(tx))
+
+	_, err := j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+	require.NoError(t, j.close())
+
+	// simulate a partially written record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte{0x0, 0x0, 0x1})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	loaded, _, err := newJournal(path).load(func(*types.Transaction) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, errJournalCorrupted)
+	assert.Equal(t, 1, loaded)
+}
+
+func TestJournal_ReplayIntoPool(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+	key, sender := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100, true)
+
+	newJournaledPool := func() *TxPool {
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:          defaultPriceLimit,
+				MaxSlots:            defaultMaxSlots,
+				MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+				PriceBump:           DefaultPriceBump,
+				DeploymentWhitelist: []types.Address{},
+				JournalPath:         path,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signer)
+		require.NoError(t, pool.Start())
+
+		return pool
+	}
+
+	pool := newJournaledPool()
+
+	hashes := make([]types.Hash, 2)
+
+	for i := range hashes {
+		signedTx, err := signer.SignTx(newTx(types.ZeroAddress, uint64(i), 1), key)
+		require.NoError(t, err)
+
+		require.NoError(t, pool.AddTx(signedTx))
+
+		hashes[i] = signedTx.Hash
+	}
+
+	// gossiped transactions aren't journaled
+	gossipTx, err := signer.SignTx(newTx(types.ZeroAddress, 2, 1), key)
+	require.NoError(t, err)
+	require.NoError(t, pool.addTx(gossip, gossipTx))
+
+	pool.Close()
+
+	// the local transactions are replayed on restart
+	pool = newJournaledPool()
+	defer pool.Close()
+
+	for _, hash := range hashes {
+		tx, ok := pool.index.get(hash)
+		require.True(t, ok)
+		assert.Equal(t, sender, tx.From)
+	}
+
+	_, ok := pool.index.get(gossipTx.Hash)
+	assert.False(t, ok)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var mockHeader = &types.Header{
@@ -66,3 +68,11 @@ type mockSigner struct {
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+type mockPenalizer struct {
+	penalized []peer.ID
+}
+
+func (m *mockPenalizer) PenalizePeer(peerID peer.ID, _ network.Penalty, _ string) {
+	m.penalized = append(m.penalized, peerID)
+}
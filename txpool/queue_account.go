@@ -71,6 +71,43 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	return
 }
 
+// get returns the transaction with the given nonce, or nil if there is none.
+func (q *accountQueue) get(nonce uint64) *types.Transaction {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace puts the new transaction in place of the old one with the same nonce.
+func (q *accountQueue) replace(old, tx *types.Transaction) {
+	for i, queued := range q.queue {
+		if queued == old {
+			q.queue[i] = tx
+
+			// the nonce is the same so the heap order is kept
+			return
+		}
+	}
+}
+
+// remove removes the given transaction from the queue.
+// Returns false if the transaction isn't queued.
+func (q *accountQueue) remove(tx *types.Transaction) bool {
+	for i, queued := range q.queue {
+		if queued == tx {
+			heap.Remove(&q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
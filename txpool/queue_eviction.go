@@ -0,0 +1,163 @@
+package txpool
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// minEvictionCompaction is the minimum size of the eviction queue
+// before the transactions which left the pool are discarded from it
+const minEvictionCompaction = 1024
+
+// evictionQueue is a pool-wide index of the remote transactions
+// sorted by fee cap (ascending). When the pool is full,
+// the cheapest of them are evicted to make room for better priced ones.
+// Transactions which left the pool are discarded lazily.
+type evictionQueue struct {
+	sync.Mutex
+	queue *minPriceQueue
+
+	// isLive reports whether the transaction is still in the pool
+	isLive func(*types.Transaction) bool
+
+	// number of live transactions after the last compaction
+	live int
+}
+
+func newEvictionQueue(isLive func(*types.Transaction) bool) *evictionQueue {
+	q := evictionQueue{
+		queue:  &minPriceQueue{},
+		isLive: isLive,
+	}
+
+	heap.Init(q.queue)
+
+	return &q
+}
+
+// push adds the transaction to the queue,
+// discarding the stale transactions once they outnumber the live ones.
+func (q *evictionQueue) push(tx *types.Transaction) {
+	q.Lock()
+	defer q.Unlock()
+
+	heap.Push(q.queue, tx)
+
+	if n := q.queue.Len(); n >= minEvictionCompaction && n >= 2*q.live {
+		q.compact()
+	}
+}
+
+// compact discards all transactions which left the pool. Assumes the lock is held.
+func (q *evictionQueue) compact() {
+	live := q.queue.txs[:0]
+
+	for _, tx := range q.queue.txs {
+		if q.isLive(tx) {
+			live = append(live, tx)
+		}
+	}
+
+	q.queue.txs = live
+	q.live = len(live)
+
+	heap.Init(q.queue)
+}
+
+// underpriced pops the cheapest transactions priced lower than the given one
+// until they occupy at least the given number of slots.
+// The transactions of the same sender are skipped, evicting them would leave a nonce gap.
+// Nothing is popped and false is returned if there aren't enough of them.
+func (q *evictionQueue) underpriced(tx *types.Transaction, slots uint64) ([]*types.Transaction, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	var (
+		victims []*types.Transaction
+		skipped []*types.Transaction
+		freed   uint64
+	)
+
+	for freed < slots && q.queue.Len() != 0 {
+		cheapest := q.queue.Peek()
+
+		if !q.isLive(cheapest) {
+			heap.Pop(q.queue)
+
+			continue
+		}
+
+		if cmpFeeCap(tx, cheapest) <= 0 {
+			break
+		}
+
+		heap.Pop(q.queue)
+
+		if cheapest.From == tx.From {
+			skipped = append(skipped, cheapest)
+
+			continue
+		}
+
+		victims = append(victims, cheapest)
+		freed += slotsRequired(cheapest)
+	}
+
+	// put the transactions of the sender back
+	for _, own := range skipped {
+		heap.Push(q.queue, own)
+	}
+
+	if freed < slots {
+		// put the candidates back
+		for _, victim := range victims {
+			heap.Push(q.queue, victim)
+		}
+
+		return nil, false
+	}
+
+	return victims, true
+}
+
+// length returns the number of transactions in the queue (including stale ones).
+func (q *evictionQueue) length() int {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.queue.Len()
+}
+
+// transactions sorted by fee cap (ascending), then by tip,
+// higher nonces come first for the same price.
+// The effective tips change with the base fee of every block,
+// so the transactions are ranked by the most they are willing to pay
+type minPriceQueue struct {
+	maxPriceQueue
+}
+
+func (q *minPriceQueue) Less(i, j int) bool {
+	switch cmpFeeCap(q.txs[i], q.txs[j]) {
+	case -1:
+		return true
+	case 1:
+		return false
+	default:
+		return q.txs[i].Nonce > q.txs[j].Nonce
+	}
+}
+
+// cmpFeeCap compares the fee caps of the transactions, then their tips.
+// The gas price is both the fee cap and the tip of the non dynamic fee transactions
+func cmpFeeCap(a, b *types.Transaction) int {
+	aFeeCap, aTip := txFees(a)
+	bFeeCap, bTip := txFees(b)
+
+	if c := aFeeCap.Cmp(bFeeCap); c != 0 {
+		return c
+	}
+
+	return aTip.Cmp(bTip)
+}
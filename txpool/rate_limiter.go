@@ -0,0 +1,59 @@
+package txpool
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimitWindow is the time window the origin rate limits apply to
+const rateLimitWindow = time.Second
+
+// originLimiter limits the number of transactions each origin
+// (a gossip peer or a JSON-RPC client address) can submit per time window.
+// The counters of all origins are reset when the window elapses,
+// so the limiter only keeps track of the recently active origins.
+type originLimiter struct {
+	lock sync.Mutex
+
+	// maximum number of transactions per origin in a window, 0 disables the limit
+	limit uint64
+
+	window      time.Duration
+	windowStart time.Time
+	counts      map[string]uint64
+
+	now func() time.Time
+}
+
+func newOriginLimiter(limit uint64, window time.Duration) *originLimiter {
+	return &originLimiter{
+		limit:  limit,
+		window: window,
+		counts: make(map[string]uint64),
+		now:    time.Now,
+	}
+}
+
+// allow counts a transaction of the given origin.
+// Returns false if the origin exceeded its limit in the current window
+func (l *originLimiter) allow(origin string) bool {
+	if l.limit == 0 {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if now := l.now(); now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now
+		l.counts = make(map[string]uint64)
+	}
+
+	if l.counts[origin] >= l.limit {
+		return false
+	}
+
+	l.counts[origin]++
+
+	return true
+}
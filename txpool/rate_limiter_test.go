@@ -0,0 +1,42 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	limiter := newOriginLimiter(2, time.Second)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	assert.True(t, limiter.allow("origin1"))
+	assert.True(t, limiter.allow("origin1"))
+	assert.False(t, limiter.allow("origin1"))
+
+	// origins are limited separately
+	assert.True(t, limiter.allow("origin2"))
+
+	// the limits are reset in the next window
+	now = now.Add(time.Second)
+
+	assert.True(t, limiter.allow("origin1"))
+	assert.Len(t, limiter.counts, 1)
+}
+
+func TestOriginLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	limiter := newOriginLimiter(0, time.Second)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, limiter.allow("origin"))
+	}
+}
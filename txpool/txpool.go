@@ -35,6 +35,14 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// DefaultPriceBump is the default minimum price increase (in percents)
+	// required for replacing a transaction with the same nonce
+	DefaultPriceBump uint64 = 10
+
+	// journalRotationInterval is the interval of dropping
+	// the transactions which left the pool from the journal
+	journalRotationInterval = time.Hour
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -60,6 +68,9 @@ var (
 	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
 	ErrTipVeryHigh             = errors.New("max priority fee per gas higher than 2^256-1")
 	ErrFeeCapVeryHigh          = errors.New("max fee per gas higher than 2^256-1")
+	ErrReplacementUnderpriced  = errors.New("replacement transaction underpriced")
+	ErrMaxPromotedLimitReached = errors.New("maximum number of promoted slots per account reached")
+	ErrTxRateLimited           = errors.New("transaction rate limit exceeded")
 )
 
 // indicates origin of a transaction
@@ -93,11 +104,28 @@ type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// peerPenalizer penalizes the peers gossiping invalid transactions
+type peerPenalizer interface {
+	PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string)
+}
+
 type Config struct {
 	PriceLimit          uint64
 	MaxSlots            uint64
 	MaxAccountEnqueued  uint64
+	PriceBump           uint64
 	DeploymentWhitelist []types.Address
+	// MaxAccountPromoted is the maximum number of slots
+	// the promoted transactions of an account can occupy, 0 if unlimited
+	MaxAccountPromoted uint64
+	// PeerTxRateLimit is the maximum number of transactions
+	// accepted from a gossip peer per second, 0 if unlimited
+	PeerTxRateLimit uint64
+	// RPCTxRateLimit is the maximum number of transactions
+	// accepted from a JSON-RPC client address per second, 0 if unlimited
+	RPCTxRateLimit uint64
+	// JournalPath is the path of the local transactions journal, disabled if empty
+	JournalPath string
 }
 
 /* All requests are passed to the main loop
@@ -154,7 +182,14 @@ type TxPool struct {
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic     *network.Topic
+	penalizer peerPenalizer
+
+	// journal of the local transactions, nil if disabled
+	journal *journal
+
+	// remote transactions sorted by price, evicted when the pool is full
+	remotes *evictionQueue
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -162,6 +197,14 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum price increase (in percents)
+	// for replacing a transaction with the same nonce
+	priceBump uint64
+
+	// rate limits of the transactions per gossip peer and per JSON-RPC client
+	peerLimiter *originLimiter
+	rpcLimiter  *originLimiter
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -242,10 +285,16 @@ func NewTxPool(
 		forks:       forks,
 		store:       store,
 		executables: newPricedQueue(),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		accounts: accountsMap{
+			maxEnqueuedLimit: config.MaxAccountEnqueued,
+			maxPromotedLimit: config.MaxAccountPromoted,
+		},
 		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
 		gauge:       slotGauge{height: 0, max: config.MaxSlots},
 		priceLimit:  config.PriceLimit,
+		priceBump:   config.PriceBump,
+		peerLimiter: newOriginLimiter(config.PeerTxRateLimit, rateLimitWindow),
+		rpcLimiter:  newOriginLimiter(config.RPCTxRateLimit, rateLimitWindow),
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -257,18 +306,25 @@ func NewTxPool(
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	pool.remotes = newEvictionQueue(func(tx *types.Transaction) bool {
+		current, ok := pool.index.get(tx.Hash)
+
+		return ok && current == tx
+	})
+
 	if network != nil {
-		// subscribe to the gossip protocol
+		// the gossip topic is subscribed to once the pool is started
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
 		if err != nil {
 			return nil, err
 		}
 
-		if subscribeErr := topic.Subscribe(pool.addGossipTx); subscribeErr != nil {
-			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
-		}
-
 		pool.topic = topic
+		pool.penalizer = network
+	}
+
+	if config.JournalPath != "" {
+		pool.journal = newJournal(config.JournalPath)
 	}
 
 	// initialize deployment whitelist
@@ -286,10 +342,22 @@ func (p *TxPool) updatePending(i int64) {
 	metrics.SetGauge([]string{txPoolMetrics, "pending_transactions"}, float32(newPending))
 }
 
+// incrRejectedTxs increases the counter of the transactions
+// rejected by the admission limits for the given reason
+func incrRejectedTxs(reason string) {
+	metrics.IncrCounterWithLabels(
+		[]string{txPoolMetrics, "rejected_transactions"},
+		1,
+		[]metrics.Label{{Name: "reason", Value: reason}},
+	)
+}
+
 // Start runs the pool's main loop in the background.
 // On each request received, the appropriate handler
 // is invoked in a separate goroutine.
-func (p *TxPool) Start() {
+// The local transactions journal is replayed
+// before subscribing to the gossip protocol.
+func (p *TxPool) Start() error {
 	// set default value of txpool pending transactions gauge
 	p.updatePending(0)
 
@@ -322,12 +390,89 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.journal != nil {
+		p.loadJournal()
+
+		go p.runJournalRotation()
+	}
+
+	if p.topic != nil {
+		if err := p.topic.Subscribe(p.addGossipTx); err != nil {
+			return fmt.Errorf("unable to subscribe to gossip topic, %w", err)
+		}
+	}
+
+	return nil
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
-	p.shutdownCh <- struct{}{}
+	close(p.shutdownCh)
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+}
+
+// loadJournal replays the journaled local transactions into the pool
+// and drops the ones that weren't accepted from the journal
+func (p *TxPool) loadJournal() {
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		return p.addTx(local, tx)
+	})
+	if err != nil {
+		p.logger.Error("failed to load the journal", "err", err)
+	}
+
+	p.logger.Info("loaded the journal", "transactions", loaded, "dropped", dropped)
+
+	p.rotateJournal()
+}
+
+// runJournalRotation rotates the journal periodically until the pool is closed
+func (p *TxPool) runJournalRotation() {
+	ticker := time.NewTicker(journalRotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.rotateJournal()
+		}
+	}
+}
+
+// rotateJournal drops the transactions which left the pool from the journal
+func (p *TxPool) rotateJournal() {
+	kept, err := p.journal.rotate(func(tx *types.Transaction) bool {
+		_, ok := p.index.get(tx.Hash)
+
+		return ok
+	})
+	if err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+
+		return
+	}
+
+	p.logger.Debug("rotated the journal", "transactions", kept)
+}
+
+// journalTx records the local transaction in the journal
+func (p *TxPool) journalTx(tx *types.Transaction) {
+	if p.journal == nil {
+		return
+	}
+
+	if err := p.journal.insert(tx); err != nil {
+		p.logger.Error("failed to journal tx", "hash", tx.Hash.String(), "err", err)
+	}
 }
 
 // SetSigner sets the signer the pool will use
@@ -341,6 +486,16 @@ func (p *TxPool) SetSealing(sealing bool) {
 	p.sealing.CompareAndSwap(p.sealing.Load(), sealing)
 }
 
+// SubscribeTxEvents subscribes to the given types of the pool events.
+// The returned function cancels the subscription and closes the channel
+func (p *TxPool) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
@@ -367,6 +522,18 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+// AllowRPCTx counts a transaction sent by the given JSON-RPC client address
+// against its rate limit. Returns ErrTxRateLimited if the limit is exceeded
+func (p *TxPool) AllowRPCTx(remoteAddr string) error {
+	if !p.rpcLimiter.allow(remoteAddr) {
+		incrRejectedTxs("rpc_rate_limit")
+
+		return ErrTxRateLimited
+	}
+
+	return nil
+}
+
 // Prepare generates all the transactions
 // ready for execution. (primaries)
 func (p *TxPool) Prepare(baseFee uint64) {
@@ -612,11 +779,16 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 			return ErrSmartContractRestricted
 		}
 
-		if p.forks.EIP158 && len(tx.Input) > state.TxPoolMaxInitCodeSize {
-			return runtime.ErrMaxCodeSizeExceeded
+		if p.forks.Shanghai && len(tx.Input) > runtime.MaxInitCodeSize {
+			return runtime.ErrMaxInitCodeSizeExceeded
 		}
 	}
 
+	// Reject access list tx if berlin hardfork is not enabled
+	if tx.Type == types.AccessListTx && !p.forks.Berlin {
+		return ErrInvalidTxType
+	}
+
 	if tx.Type == types.DynamicFeeTx {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !p.forks.London {
@@ -670,7 +842,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul, p.forks.Shanghai)
 	if err != nil {
 		return err
 	}
@@ -749,11 +921,6 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
-	// check for overflow
-	if slotsRequired(tx) > p.gauge.max-p.gauge.read() {
-		return ErrTxPoolOverflow
-	}
-
 	tx.ComputeHash()
 
 	// add to index
@@ -761,16 +928,138 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		return ErrAlreadyKnown
 	}
 
+	// limit the slots a single account can occupy with the promoted transactions
+	if account := p.accounts.get(tx.From); account != nil && account.promotedLimitReached(tx) {
+		p.index.remove(tx)
+		incrRejectedTxs("account_promoted_limit")
+
+		return ErrMaxPromotedLimitReached
+	}
+
+	// check the replacement or the enqueue before making room for the transaction,
+	// so that a rejected transaction doesn't evict any other one
+	var replaceable *types.Transaction
+
+	if account := p.accounts.get(tx.From); account != nil {
+		var err error
+
+		if replaceable, err = account.replaceable(tx, p.priceBump); err == nil && replaceable == nil {
+			err = account.enqueueable(tx)
+		}
+
+		if err != nil {
+			p.index.remove(tx)
+
+			return err
+		}
+	}
+
+	// check for overflow
+	if err := p.makeRoom(tx, replaceable); err != nil {
+		p.index.remove(tx)
+
+		return err
+	}
+
 	// initialize account for this address once
 	p.createAccountOnce(tx.From)
 
-	// send request [BLOCKING]
-	p.enqueueReqCh <- enqueueRequest{tx: tx}
+	// replace the transaction with the same nonce if there is one
+	replaced, wasPromoted, err := p.accounts.get(tx.From).replace(tx, p.priceBump)
+	if err != nil {
+		p.index.remove(tx)
+
+		return err
+	}
+
+	if replaced != nil {
+		p.index.remove(replaced)
+		p.gauge.increase(slotsRequired(tx))
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEvent(proto.EventType_REPLACED, replaced.Hash)
+
+		p.logger.Debug("replaced tx",
+			"old", replaced.Hash.String(),
+			"new", tx.Hash.String(),
+		)
+	} else {
+		// send request [BLOCKING]
+		p.enqueueReqCh <- enqueueRequest{tx: tx}
+	}
+
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
 
+	// the replacement of a promoted transaction is promoted right away
+	if wasPromoted {
+		p.eventManager.signalEvent(proto.EventType_PROMOTED, tx.Hash)
+	}
+
+	if origin == local {
+		p.journalTx(tx)
+	} else {
+		// only remote transactions can be evicted
+		p.remotes.push(tx)
+	}
+
 	return nil
 }
 
+// makeRoom checks if there are enough free slots for the transaction.
+// The slots of the transaction it replaces (if any) are released by the replacement.
+// If the pool is full, the cheapest remote transactions
+// priced lower than the given one are evicted to make room for it.
+func (p *TxPool) makeRoom(tx, replaced *types.Transaction) error {
+	var free uint64
+	if used := p.gauge.read(); used < p.gauge.max {
+		free = p.gauge.max - used
+	}
+
+	if replaced != nil {
+		free += slotsRequired(replaced)
+	}
+
+	required := slotsRequired(tx)
+	if required <= free {
+		return nil
+	}
+
+	victims, ok := p.remotes.underpriced(tx, required-free)
+	if !ok {
+		return ErrTxPoolOverflow
+	}
+
+	for _, victim := range victims {
+		p.evict(victim)
+	}
+
+	return nil
+}
+
+// evict removes the remote transaction from the pool
+// to make room for a better priced one.
+func (p *TxPool) evict(tx *types.Transaction) {
+	wasPromoted, demoted, ok := p.accounts.get(tx.From).evict(tx)
+	if !ok {
+		// the transaction has left the pool meanwhile
+		return
+	}
+
+	p.index.remove(tx)
+	p.gauge.decrease(slotsRequired(tx))
+
+	if wasPromoted {
+		// update metrics
+		p.updatePending(-1 - int64(len(demoted)))
+	}
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+	p.logger.Debug("evicted underpriced tx",
+		"hash", tx.Hash.String(),
+		"demoted", len(demoted),
+	)
+}
+
 // handleEnqueueRequest attempts to enqueue the transaction
 // contained in the given request to the associated account.
 // If, afterwards, the account is eligible for promotion,
@@ -828,11 +1117,18 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, peerID peer.ID) {
 	if !p.sealing.Load() {
 		return
 	}
 
+	if !p.peerLimiter.allow(peerID.String()) {
+		incrRejectedTxs("peer_rate_limit")
+		p.logger.Debug("rejecting gossiped tx", "peer", peerID, "err", ErrTxRateLimited)
+
+		return
+	}
+
 	raw, ok := obj.(*proto.Txn)
 	if !ok {
 		p.logger.Error("failed to cast gossiped message to txn")
@@ -852,6 +1148,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.penalizePeer(peerID, "undecodable tx")
 
 		return
 	}
@@ -865,9 +1162,44 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		if isInvalidTx(err) {
+			p.penalizePeer(peerID, err.Error())
+		}
 	}
 }
 
+// penalizePeer penalizes the peer for gossiping an invalid transaction
+func (p *TxPool) penalizePeer(peerID peer.ID, reason string) {
+	if p.penalizer == nil {
+		return
+	}
+
+	p.penalizer.PenalizePeer(peerID, network.PenaltyInvalidTx, reason)
+}
+
+// isInvalidTx checks if the transaction was rejected for being invalid regardless of
+// the state of the pool, i.e. a well-behaved peer would never have gossiped it
+func isInvalidTx(err error) bool {
+	for _, invalidErr := range []error{
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrIntrinsicGas,
+		ErrNegativeValue,
+		ErrOversizedData,
+		ErrInvalidTxType,
+		ErrTipAboveFeeCap,
+		ErrTipVeryHigh,
+		ErrFeeCapVeryHigh,
+	} {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
 func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	if len(stateNonces) == 0 {
@@ -13,12 +13,12 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
-	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -95,6 +95,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 			PriceLimit:          defaultPriceLimit,
 			MaxSlots:            maxSlots,
 			MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+			PriceBump:           DefaultPriceBump,
 			DeploymentWhitelist: []types.Address{},
 		},
 	)
@@ -631,6 +632,402 @@ func TestDropKnownGossipTx(t *testing.T) {
 	)
 }
 
+func TestReplaceTx(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(nonce, slots, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, slots)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	// enqueueTx adds the tx to the pool and handles its enqueue request
+	enqueueTx := func(t *testing.T, pool *TxPool, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	t.Run("replace enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		oldTx := newPricedTx(1, 1, 100)
+		enqueueTx(t, pool, oldTx)
+
+		replacement := newPricedTx(1, 3, 110)
+		assert.NoError(t, pool.addTx(local, replacement))
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), acc.enqueued.length())
+		assert.Equal(t, replacement.Hash, acc.enqueued.peek().Hash)
+		assert.Equal(t, uint64(3), pool.gauge.read())
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.False(t, exists)
+
+		_, exists = pool.index.get(replacement.Hash)
+		assert.True(t, exists)
+	})
+
+	t.Run("replace promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		oldTx := newPricedTx(0, 2, 100)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, oldTx))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+		replacement := newPricedTx(0, 1, 200)
+		assert.NoError(t, pool.addTx(local, replacement))
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(0), acc.enqueued.length())
+		assert.Equal(t, uint64(1), acc.promoted.length())
+		assert.Equal(t, replacement.Hash, acc.promoted.peek().Hash)
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.Length())
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.False(t, exists)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		// the subscribers track the replacement as promoted
+		promoted := waitForEvents(ctx, subscription, 1)
+		require.Len(t, promoted, 1)
+		assert.Equal(t, replacement.Hash.String(), promoted[0].TxHash)
+	})
+
+	t.Run("reject underpriced replacement", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		oldTx := newPricedTx(1, 1, 100)
+		enqueueTx(t, pool, oldTx)
+
+		for _, gasPrice := range []uint64{90, 100, 109} {
+			tx := newPricedTx(1, 1, gasPrice)
+
+			assert.ErrorIs(t, pool.addTx(local, tx), ErrReplacementUnderpriced)
+
+			_, exists := pool.index.get(tx.Hash)
+			assert.False(t, exists)
+		}
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), acc.enqueued.length())
+		assert.Equal(t, oldTx.Hash, acc.enqueued.peek().Hash)
+		assert.Equal(t, uint64(1), pool.gauge.read())
+	})
+}
+
+func TestEvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	// enqueueTx adds the tx to the pool and handles its enqueue request
+	enqueueTx := func(t *testing.T, pool *TxPool, origin txOrigin, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(origin, tx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	t.Run("evict the cheapest remote tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(3)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+
+		cheapest := newPricedTx(addr1, 1, 1)
+
+		enqueueTx(t, pool, gossip, cheapest)
+		enqueueTx(t, pool, gossip, newPricedTx(addr2, 1, 2))
+		enqueueTx(t, pool, gossip, newPricedTx(addr3, 1, 3))
+
+		// not priced higher than the cheapest remaining one
+		assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr4, 1, 1)), ErrTxPoolOverflow)
+
+		enqueueTx(t, pool, local, newPricedTx(addr4, 1, 5))
+
+		assert.Equal(t, uint64(3), pool.gauge.read())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr4).enqueued.length())
+
+		_, exists := pool.index.get(cheapest.Hash)
+		assert.False(t, exists)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		dropped := waitForEvents(ctx, subscription, 1)
+		require.Len(t, dropped, 1)
+		assert.Equal(t, cheapest.Hash.String(), dropped[0].TxHash)
+	})
+
+	t.Run("never evict local txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		enqueueTx(t, pool, local, newPricedTx(addr1, 1, 1))
+		enqueueTx(t, pool, local, newPricedTx(addr2, 1, 1))
+
+		assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr3, 1, 100)), ErrTxPoolOverflow)
+		assert.ErrorIs(t, pool.addTx(local, newPricedTx(addr3, 1, 100)), ErrTxPoolOverflow)
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+	})
+
+	t.Run("replacement reuses the slots of the replaced tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		remote := newPricedTx(addr2, 1, 1)
+
+		enqueueTx(t, pool, gossip, remote)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, newPricedTx(addr1, 0, 100)))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		assert.NoError(t, pool.addTx(local, newPricedTx(addr1, 0, 200)))
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+
+		_, exists := pool.index.get(remote.Hash)
+		assert.True(t, exists)
+	})
+
+	t.Run("rejected replacement doesn't evict", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		remote := newPricedTx(addr2, 1, 1)
+
+		enqueueTx(t, pool, gossip, remote)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, newPricedTx(addr1, 0, 100)))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		// the bigger replacement would need the slot of the remote tx
+		replacement := newTx(addr1, 0, 2)
+		replacement.GasPrice = big.NewInt(105)
+
+		assert.ErrorIs(t, pool.addTx(local, replacement), ErrReplacementUnderpriced)
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+
+		_, exists := pool.index.get(remote.Hash)
+		assert.True(t, exists)
+	})
+
+	t.Run("rejected enqueue doesn't evict", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		pool.accounts.maxEnqueuedLimit = 1
+
+		remote := newPricedTx(addr2, 1, 1)
+
+		enqueueTx(t, pool, local, newPricedTx(addr1, 5, 1))
+		enqueueTx(t, pool, gossip, remote)
+
+		// the enqueued queue of the account is full
+		assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr1, 0, 100)), ErrMaxEnqueuedLimitReached)
+
+		_, exists := pool.index.get(remote.Hash)
+		assert.True(t, exists)
+		assert.Equal(t, uint64(2), pool.gauge.read())
+	})
+
+	t.Run("never evict the txs of the sender", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		own := newPricedTx(addr1, 0, 1)
+		other := newPricedTx(addr2, 1, 2)
+
+		enqueueTx(t, pool, gossip, other)
+
+		for _, tx := range []*types.Transaction{own, newPricedTx(addr1, 1, 100)} {
+			tx := tx
+
+			go func() {
+				assert.NoError(t, pool.addTx(gossip, tx))
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		_, exists := pool.index.get(own.Hash)
+		assert.True(t, exists)
+
+		_, exists = pool.index.get(other.Hash)
+		assert.False(t, exists)
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+	})
+
+	t.Run("rank the dynamic fee txs by fee cap", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		dynamic := newTx(addr1, 1, 1)
+		dynamic.Type = types.DynamicFeeTx
+		dynamic.GasPrice = nil
+		dynamic.GasFeeCap = big.NewInt(100)
+		dynamic.GasTipCap = big.NewInt(1)
+
+		legacy := newPricedTx(addr2, 1, 2)
+
+		enqueueTx(t, pool, gossip, dynamic)
+		enqueueTx(t, pool, gossip, legacy)
+		enqueueTx(t, pool, gossip, newPricedTx(addr3, 1, 50))
+
+		_, exists := pool.index.get(dynamic.Hash)
+		assert.True(t, exists)
+
+		_, exists = pool.index.get(legacy.Hash)
+		assert.False(t, exists)
+	})
+
+	t.Run("demote the txs following an evicted promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		cheapest := newPricedTx(addr1, 0, 1)
+
+		for _, tx := range []*types.Transaction{cheapest, newPricedTx(addr1, 1, 10)} {
+			tx := tx
+
+			go func() {
+				assert.NoError(t, pool.addTx(gossip, tx))
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(2), acc.promoted.length())
+		assert.Equal(t, uint64(2), acc.getNonce())
+
+		enqueueTx(t, pool, gossip, newPricedTx(addr2, 1, 5))
+
+		assert.Equal(t, uint64(0), acc.promoted.length())
+		assert.Equal(t, uint64(1), acc.enqueued.length())
+		assert.Equal(t, uint64(1), acc.enqueued.peek().Nonce)
+		assert.Equal(t, uint64(0), acc.getNonce())
+		assert.Equal(t, uint64(2), pool.gauge.read())
+
+		_, exists := pool.index.get(cheapest.Hash)
+		assert.False(t, exists)
+	})
+}
+
+func TestIsPriceBumped(t *testing.T) {
+	t.Parallel()
+
+	dynamicTx := func(feeCap, tip int64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasFeeCap: big.NewInt(feeCap),
+			GasTipCap: big.NewInt(tip),
+		}
+	}
+
+	legacyTx := func(gasPrice int64) *types.Transaction {
+		return &types.Transaction{
+			Type:     types.LegacyTx,
+			GasPrice: big.NewInt(gasPrice),
+		}
+	}
+
+	cases := []struct {
+		name     string
+		old      *types.Transaction
+		new      *types.Transaction
+		expected bool
+	}{
+		{"legacy bumped", legacyTx(100), legacyTx(110), true},
+		{"legacy not bumped enough", legacyTx(100), legacyTx(109), false},
+		{"legacy same price", legacyTx(100), legacyTx(100), false},
+		{"dynamic bumped", dynamicTx(100, 10), dynamicTx(110, 11), true},
+		{"dynamic only fee cap bumped", dynamicTx(100, 10), dynamicTx(200, 10), false},
+		{"dynamic only tip bumped", dynamicTx(100, 10), dynamicTx(100, 20), false},
+		{"dynamic tip not bumped enough", dynamicTx(100, 100), dynamicTx(110, 109), false},
+		{"legacy replaced by dynamic", legacyTx(100), dynamicTx(110, 110), true},
+		{"dynamic replaced by legacy", dynamicTx(100, 10), legacyTx(110), true},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.expected, isPriceBumped(c.old, c.new, DefaultPriceBump))
+		})
+	}
+}
+
 func TestEnqueueHandler(t *testing.T) {
 	t.Parallel()
 
@@ -668,12 +1065,11 @@ func TestEnqueueHandler(t *testing.T) {
 			acc := pool.createAccountOnce(addr1)
 			acc.setNonce(20)
 
-			// send tx
-			go func() {
-				err := pool.addTx(local, newTx(addr1, 10, 1)) // 10 < 20
-				assert.NoError(t, err)
-			}()
-			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			// send tx, it is rejected before the enqueue request
+			assert.ErrorIs(t,
+				pool.addTx(local, newTx(addr1, 10, 1)), // 10 < 20
+				ErrNonceTooLow,
+			)
 
 			assert.Equal(t, uint64(0), pool.gauge.read())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
@@ -745,14 +1141,11 @@ func TestEnqueueHandler(t *testing.T) {
 			assert.Equal(t, uint64(1), pool.gauge.read())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
 
-			//	send next expected tx
-			go func() {
-				assert.NoError(t,
-					pool.addTx(local, newTx(addr1, 1, 1)),
-				)
-			}()
-
-			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			//	send next expected tx, it is rejected before the enqueue request
+			assert.ErrorIs(t,
+				pool.addTx(local, newTx(addr1, 1, 1)),
+				ErrMaxEnqueuedLimitReached,
+			)
 
 			//	assert the transaction was rejected
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
@@ -1751,12 +2144,12 @@ func TestPermissionSmartContractDeployment(t *testing.T) {
 		)
 	})
 
-	t.Run("Input larger than the TxPoolMaxInitCodeSize", func(t *testing.T) {
+	t.Run("Input larger than the MaxInitCodeSize", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
-		pool.forks.EIP158 = true
+		pool.forks.Shanghai = true
 
-		input := make([]byte, state.TxPoolMaxInitCodeSize+1)
+		input := make([]byte, runtime.MaxInitCodeSize+1)
 		_, err := rand.Read(input)
 		require.NoError(t, err)
 
@@ -1766,16 +2159,35 @@ func TestPermissionSmartContractDeployment(t *testing.T) {
 
 		assert.ErrorIs(t,
 			pool.validateTx(signTx(tx)),
-			runtime.ErrMaxCodeSizeExceeded,
+			runtime.ErrMaxInitCodeSizeExceeded,
+		)
+	})
+
+	t.Run("Input larger than the MaxInitCodeSize before Shanghai", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.forks.Shanghai = false
+
+		input := make([]byte, runtime.MaxInitCodeSize+1)
+		_, err := rand.Read(input)
+		require.NoError(t, err)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+		tx.Input = input
+
+		assert.NotErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			runtime.ErrMaxInitCodeSizeExceeded,
 		)
 	})
 
-	t.Run("Input the same as TxPoolMaxInitCodeSize", func(t *testing.T) {
+	t.Run("Input the same as MaxInitCodeSize", func(t *testing.T) {
 		t.Parallel()
 		pool := setupPool()
-		pool.forks.EIP158 = true
+		pool.forks.Shanghai = true
 
-		input := make([]byte, state.TxPoolMaxInitCodeSize)
+		input := make([]byte, runtime.MaxInitCodeSize)
 		_, err := rand.Read(input)
 		require.NoError(t, err)
 
@@ -1785,7 +2197,7 @@ func TestPermissionSmartContractDeployment(t *testing.T) {
 
 		assert.NoError(t,
 			pool.validateTx(signTx(tx)),
-			runtime.ErrMaxCodeSizeExceeded,
+			runtime.ErrMaxInitCodeSizeExceeded,
 		)
 	})
 
@@ -2940,3 +3352,163 @@ func TestBatchTx_SingleAccount(t *testing.T) {
 		}
 	}
 }
+
+func TestMaxAccountPromoted(t *testing.T) {
+	t.Parallel()
+
+	newPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		pool.accounts.maxPromotedLimit = 2
+
+		return pool
+	}
+
+	// addPromotedTx adds the tx to the pool and handles its promotion
+	addPromotedTx := func(t *testing.T, pool *TxPool, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	t.Run("reject txs over the limit", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+
+		promoted := newTx(addr1, 1, 1)
+
+		addPromotedTx(t, pool, newTx(addr1, 0, 1))
+		addPromotedTx(t, pool, promoted)
+
+		assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 2, 1)), ErrMaxPromotedLimitReached)
+
+		// the known txs are reported as such
+		assert.ErrorIs(t, pool.addTx(local, promoted.Copy()), ErrAlreadyKnown)
+
+		// future txs are only enqueued
+		go func() {
+			assert.NoError(t, pool.addTx(local, newTx(addr1, 3, 1)))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+		// replacements don't take more slots
+		replacement := newTx(addr1, 1, 1)
+		replacement.GasPrice = new(big.Int).SetUint64(2 * defaultPriceLimit)
+
+		assert.NoError(t, pool.addTx(local, replacement))
+
+		// other accounts aren't limited
+		addPromotedTx(t, pool, newTx(addr2, 0, 1))
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr2).promoted.length())
+	})
+
+	t.Run("promote up to the limit", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t)
+
+		for nonce := uint64(1); nonce <= 2; nonce++ {
+			tx := newTx(addr1, nonce, 1)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, tx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		}
+
+		addPromotedTx(t, pool, newTx(addr1, 0, 1))
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(2), acc.promoted.length())
+		assert.Equal(t, uint64(1), acc.enqueued.length())
+		assert.Equal(t, uint64(2), acc.getNonce())
+
+		// the promotion continues once the promoted txs are executed
+		go acc.reset(1, pool.promoteReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		assert.Equal(t, uint64(2), acc.promoted.length())
+		assert.Equal(t, uint64(0), acc.enqueued.length())
+		assert.Equal(t, uint64(3), acc.getNonce())
+	})
+}
+
+func TestRateLimitedGossipTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+	pool.SetSealing(true)
+
+	pool.peerLimiter = newOriginLimiter(1, time.Hour)
+
+	gossipTx := func(tx *types.Transaction, peerID peer.ID) {
+		pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: tx.MarshalRLP()}}, peerID)
+	}
+
+	txs := []*types.Transaction{
+		newTx(types.ZeroAddress, 1, 1),
+		newTx(types.ZeroAddress, 2, 1),
+		newTx(types.ZeroAddress, 3, 1),
+	}
+
+	go gossipTx(txs[0], peer.ID("peer1"))
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	// the peer exceeded its limit
+	gossipTx(txs[1], peer.ID("peer1"))
+
+	// other peers aren't limited
+	go gossipTx(txs[2], peer.ID("peer2"))
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(2), pool.gauge.read())
+
+	for i, expected := range []bool{true, false, true} {
+		txs[i].ComputeHash()
+
+		_, exists := pool.index.get(txs[i].Hash)
+		assert.Equal(t, expected, exists)
+	}
+}
+
+func TestPenalizeInvalidGossipTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+	pool.SetSealing(true)
+
+	penalizer := &mockPenalizer{}
+	pool.penalizer = penalizer
+
+	// oversized transactions are invalid
+	oversizedTx := newTx(types.ZeroAddress, 0, 5)
+	pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: oversizedTx.MarshalRLP()}}, peer.ID("peer1"))
+
+	// so are undecodable ones
+	pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: []byte{0x1}}}, peer.ID("peer2"))
+
+	// underpriced transactions might be valid for the sender
+	underpricedTx := newTx(types.ZeroAddress, 0, 1)
+	underpricedTx.GasPrice = big.NewInt(0)
+	pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: underpricedTx.MarshalRLP()}}, peer.ID("peer3"))
+
+	assert.Equal(t, []peer.ID{"peer1", "peer2"}, penalizer.penalized)
+	assert.Equal(t, uint64(0), pool.gauge.read())
+}
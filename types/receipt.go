@@ -92,14 +92,7 @@ func CreateBloom(receipts []*Receipt) (b Bloom) {
 }
 
 func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
-	hasher.Reset()
-	hasher.Write(h[:]) //nolint:errcheck
-	buf := hasher.Read()
-
-	for i := 0; i < 6; i += 2 {
-		// Find the global bit location
-		bit := (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomByteLength*8 - 1)
-
+	for _, bit := range bloomBitIndexes(hasher, h) {
 		// Find where the bit maps in the [0..BloomByteLength-1] byte array
 		byteLocation := BloomByteLength - 1 - bit/8
 		bitLocation := bit % 8
@@ -107,6 +100,15 @@ func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
 	}
 }
 
+// IsBitSet checks if the bit at the global bit location is set in the bloom filter
+func (b *Bloom) IsBitSet(bit uint) bool {
+	// Find where the bit maps in the [0..BloomByteLength-1] byte array
+	byteLocation := BloomByteLength - 1 - bit/8
+	bitLocation := bit % 8
+
+	return b[byteLocation]&(1<<bitLocation) != 0
+}
+
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
 func (b *Bloom) IsLogInBloom(log *Log) bool {
 	hasher := keccak.DefaultKeccakPool.Get()
@@ -129,23 +131,33 @@ func (b *Bloom) IsLogInBloom(log *Log) bool {
 
 // isByteArrPresent checks if the byte array is possibly present in the Bloom filter
 func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
+	for _, bit := range bloomBitIndexes(hasher, data) {
+		if !b.IsBitSet(bit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BloomBitIndexes returns the global locations of the bits
+// the byte array sets in a bloom filter
+func BloomBitIndexes(data []byte) [3]uint {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	return bloomBitIndexes(hasher, data)
+}
+
+func bloomBitIndexes(hasher *keccak.Keccak, data []byte) (bits [3]uint) {
 	hasher.Reset()
 	hasher.Write(data[:]) //nolint:errcheck
 	buf := hasher.Read()
 
-	for i := 0; i < 6; i += 2 {
+	for i := range bits {
 		// Find the global bit location
-		bit := (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomByteLength*8 - 1)
-
-		// Find where the bit maps in the [0..BloomByteLength-1] byte array
-		byteLocation := BloomByteLength - 1 - bit/8
-		bitLocation := bit % 8
-		isSet := b[byteLocation] & (1 << bitLocation)
-
-		if isSet == 0 {
-			return false
-		}
+		bits[i] = (uint(buf[2*i+1]) + (uint(buf[2*i]) << 8)) & (BloomByteLength*8 - 1)
 	}
 
-	return true
+	return bits
 }
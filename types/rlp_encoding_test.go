@@ -153,6 +153,7 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
@@ -173,12 +174,61 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_AccessList(t *testing.T) {
+	t.Parallel()
+
+	addrTo := StringToAddress("11")
+	accessList := TxAccessList{
+		{
+			Address:     StringToAddress("22"),
+			StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+		},
+		{
+			Address:     StringToAddress("33"),
+			StorageKeys: []Hash{},
+		},
+	}
+
+	for _, txType := range []TxType{AccessListTx, DynamicFeeTx} {
+		txType := txType
+
+		t.Run(txType.String(), func(t *testing.T) {
+			t.Parallel()
+
+			originalTx := &Transaction{
+				Type:       txType,
+				Nonce:      1,
+				GasPrice:   big.NewInt(11),
+				GasFeeCap:  big.NewInt(12),
+				GasTipCap:  big.NewInt(13),
+				Gas:        11,
+				To:         &addrTo,
+				Value:      big.NewInt(1),
+				Input:      []byte{1, 2},
+				V:          big.NewInt(25),
+				S:          big.NewInt(26),
+				R:          big.NewInt(27),
+				AccessList: accessList,
+			}
+			originalTx.ComputeHash()
+
+			unmarshalledTx := new(Transaction)
+			require.NoError(t, unmarshalledTx.UnmarshalRLP(originalTx.MarshalRLP()))
+
+			assert.Equal(t, txType, unmarshalledTx.Type)
+			assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+			assert.Equal(t, accessList, unmarshalledTx.AccessList)
+		})
+	}
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
@@ -202,11 +252,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Missing From", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    txType != DynamicFeeTx && txType != AccessListTx,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": txType != DynamicFeeTx && txType != AccessListTx,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -215,11 +265,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Address set for state tx only", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    txType != DynamicFeeTx && txType != AccessListTx,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": txType != DynamicFeeTx && txType != AccessListTx,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -268,6 +318,10 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "LegacyTx",
 			txType: LegacyTx,
 		},
+		{
+			name:   "AccessListTx",
+			txType: AccessListTx,
+		},
 		{
 			name:   "DynamicFeeTx",
 			txType: DynamicFeeTx,
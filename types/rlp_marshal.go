@@ -196,7 +196,7 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	// This is needed to have the same format as other EVM chains do.
 	// There is no chain ID in the TX object, so it is always 0 here just to be compatible.
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
 		vv.Set(arena.NewBigInt(big.NewInt(0)))
 	}
 
@@ -224,11 +224,9 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewCopyBytes(t.Input))
 
 	// Specify access list as per spec.
-	// This is needed to have the same format as other EVM chains do.
-	// There is no access list feature here, so it is always empty just to be compatible.
-	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
-		vv.Set(arena.NewArray())
+	// Check https://eips.ethereum.org/EIPS/eip-2930#specification
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
+		vv.Set(t.AccessList.MarshalRLPWith(arena))
 	}
 
 	// signature values
@@ -242,3 +240,27 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 
 	return vv
 }
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(al) == 0 {
+		return arena.NewNullArray()
+	}
+
+	vv := arena.NewArray()
+
+	for _, item := range al {
+		tuple := arena.NewArray()
+		tuple.Set(arena.NewCopyBytes(item.Address.Bytes()))
+
+		storageKeys := arena.NewArray()
+		for _, key := range item.StorageKeys {
+			storageKeys.Set(arena.NewCopyBytes(key.Bytes()))
+		}
+
+		tuple.Set(storageKeys)
+		vv.Set(tuple)
+	}
+
+	return vv
+}
@@ -398,6 +398,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 9
 	case StateTx:
 		num = 10
+	case AccessListTx:
+		num = 11
 	case DynamicFeeTx:
 		num = 12
 	default:
@@ -413,7 +415,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// Skipping Chain ID field since we don't support it (yet)
 	// This is needed to be compatible with other EVM chains and have the same format.
 	// Since we don't have a chain ID, just skip it here.
-	if t.Type == DynamicFeeTx {
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
 		_ = getElem()
 	}
 
@@ -468,11 +470,11 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	// Skipping Access List field since we don't support it.
-	// This is needed to be compatible with other EVM chains and have the same format.
-	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
-		_ = getElem()
+	// access list
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
+		if err = t.AccessList.unmarshalRLPFrom(p, getElem()); err != nil {
+			return err
+		}
 	}
 
 	// V
@@ -506,3 +508,51 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	return nil
 }
+
+// unmarshalRLPFrom unmarshals an access list in RLP format
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) == 0 {
+		*al = nil
+
+		return nil
+	}
+
+	accessList := make(TxAccessList, len(elems))
+
+	for i, elem := range elems {
+		tuple, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if numElems := len(tuple); numElems != 2 {
+			return fmt.Errorf("incorrect number of access tuple elements, expected 2 but found %d", numElems)
+		}
+
+		if err = tuple[0].GetAddr(accessList[i].Address[:]); err != nil {
+			return err
+		}
+
+		storageKeys, err := tuple[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		accessList[i].StorageKeys = make([]Hash, len(storageKeys))
+
+		for j, storageKey := range storageKeys {
+			if err = storageKey.GetHash(accessList[i].StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	*al = accessList
+
+	return nil
+}
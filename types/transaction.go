@@ -21,6 +21,7 @@ type TxType byte
 const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
+	AccessListTx TxType = 0x01
 	DynamicFeeTx TxType = 0x02
 )
 
@@ -28,7 +29,7 @@ func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, AccessListTx, DynamicFeeTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -42,6 +43,8 @@ func (t TxType) String() (s string) {
 		return "LegacyTx"
 	case StateTx:
 		return "StateTx"
+	case AccessListTx:
+		return "AccessListTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
 	}
@@ -49,6 +52,47 @@ func (t TxType) String() (s string) {
 	return
 }
 
+// AccessTuple is the element type of an access list (EIP-2930)
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the list of addresses and storage keys
+// a transaction plans to access (EIP-2930)
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+
+	return sum
+}
+
+// Copy makes a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	newAccessList := make(TxAccessList, len(al))
+
+	for i, item := range al {
+		storageKeys := make([]Hash, len(item.StorageKeys))
+		copy(storageKeys, item.StorageKeys)
+
+		newAccessList[i] = AccessTuple{
+			Address:     item.Address,
+			StorageKeys: storageKeys,
+		}
+	}
+
+	return newAccessList
+}
+
 type Transaction struct {
 	Nonce     uint64
 	GasPrice  *big.Int
@@ -64,6 +108,8 @@ type Transaction struct {
 
 	Type TxType
 
+	AccessList TxAccessList
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -124,6 +170,8 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
@@ -80,6 +80,9 @@ func TestTransactionCopy(t *testing.T) {
 		V:         big.NewInt(25),
 		S:         big.NewInt(26),
 		R:         big.NewInt(27),
+		AccessList: TxAccessList{
+			{Address: addrTo, StorageKeys: []Hash{StringToHash("1")}},
+		},
 	}
 	newTxn := txn.Copy()
 